@@ -0,0 +1,40 @@
+// purge-accounts menghapus permanen akun yang masa tenggang penghapusannya (account_deletion.grace_period) sudah lewat.
+// Post dipindahkan ke penerima yang dipilih user atau ke user ghost, komentar dianonimkan atau dihapus,
+// lalu data pribadi dihapus dari baris user. Jalankan berkala, misalnya dari cron:
+//
+//	go run ./cmd/purge-accounts
+package main
+
+import (
+	"context"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/config"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/repository"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/usecase"
+)
+
+func main() {
+	config.Load()
+	k := config.Get()
+
+	log := config.NewLogger(k)
+	db := config.NewDatabase(k, log)
+	redis := config.NewRedis(k)
+	validate := config.NewValidator(k)
+
+	auditUseCase := usecase.NewAuditUseCase(db, log, validate, repository.NewAuditLogRepository(db))
+	accountDeletionUseCase := usecase.NewAccountDeletionUseCase(db, log, validate, auditUseCase,
+		repository.NewUserRepository(log, db),
+		repository.NewPostRepository(db),
+		repository.NewCommentRepository(db),
+		repository.NewTokenRepository(redis),
+		repository.NewSessionRepository(redis),
+		k,
+	)
+
+	purged, err := accountDeletionUseCase.PurgeDue(context.Background())
+	if err != nil {
+		log.Fatal().Msgf("Purged %d account(s), some failed: %v", purged, err)
+	}
+	log.Info().Msgf("Purged %d account(s)", purged)
+}
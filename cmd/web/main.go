@@ -4,9 +4,14 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/config"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/scheduler"
 	"github.com/gofiber/contrib/otelfiber/v2"
+	"github.com/gofiber/fiber/v2"
 )
 
 func main() {
@@ -24,20 +29,50 @@ func main() {
 	validate := config.NewValidator(k)
 	app := config.NewFiber(k)
 	redis := config.NewRedis(k)
+	mailer := config.NewMailer(k, log)
+	jwtKeys := config.NewJWTKeySet(k, log)
+	oidcProviders := config.NewOIDCProviders(k, log)
+	breachChecker := config.NewBreachedPasswordChecker(k, log)
+	jobs := scheduler.New(log)
+
+	// harus didaftarkan sebelum route agar middleware dan handler berjalan di dalam span request
+	app.Use(otelfiber.Middleware())
 
 	config.Boostrap(&config.BootstrapConfig{
-		DB:       db,
-		App:      app,
-		Log:      log,
-		Validate: validate,
-		Config:   k,
-		Redis:    redis,
+		DB:            db,
+		App:           app,
+		Log:           log,
+		Validate:      validate,
+		Config:        k,
+		Redis:         redis,
+		Mailer:        mailer,
+		JWTKeys:       jwtKeys,
+		OIDCProviders: oidcProviders,
+		BreachChecker: breachChecker,
+		Scheduler:     jobs,
 	})
-	app.Use(otelfiber.Middleware())
+
+	// dengan web.prefork setiap child menjalankan main; job cukup berjalan di proses utama
+	if !fiber.IsChild() {
+		jobs.Start()
+	}
+
+	go func() {
+		quit := make(chan os.Signal, 1)
+		signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+		<-quit
+
+		log.Info().Msg("Shutting down web server")
+		if err := app.Shutdown(); err != nil {
+			log.Err(err).Msg("Error shutting down web server")
+		}
+	}()
 
 	webPort := k.String("web.port")
 	err := app.Listen(fmt.Sprintf(":%s", webPort))
 	if err != nil {
 		log.Err(err).Msg("Error starting web server")
 	}
+
+	jobs.Stop()
 }
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS app_settings;
+DROP TABLE IF EXISTS two_factor_recovery_codes;
+
+ALTER TABLE users DROP COLUMN IF EXISTS totp_enabled_at;
+ALTER TABLE users DROP COLUMN IF EXISTS totp_secret;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS invitations (
+    id SERIAL PRIMARY KEY,
+    code_hash VARCHAR(64) UNIQUE NOT NULL,
+    role user_role NOT NULL DEFAULT 'reader',
+    max_uses INT NOT NULL CHECK (max_uses > 0),
+    used_count INT NOT NULL DEFAULT 0 CHECK (used_count <= max_uses),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_by INT,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_invitation_creator FOREIGN KEY(created_by) REFERENCES users(id) ON DELETE SET NULL
+);
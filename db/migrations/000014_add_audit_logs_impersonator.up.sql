@@ -0,0 +1,4 @@
+-- impersonator_id terisi jika aksi dilakukan admin dengan token impersonation; actor_id tetap user yang ditiru
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS impersonator_id INT;
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_impersonator_created_at ON audit_logs(impersonator_id, created_at DESC) WHERE impersonator_id IS NOT NULL;
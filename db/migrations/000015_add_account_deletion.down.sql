@@ -0,0 +1,11 @@
+-- user ghost dibiarkan karena mungkin masih memiliki post dan komentar dari akun yang sudah dihapus
+ALTER TABLE comments DROP CONSTRAINT IF EXISTS fk_comment_author;
+ALTER TABLE comments ADD CONSTRAINT fk_comment_author FOREIGN KEY(author_id) REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE posts DROP CONSTRAINT IF EXISTS fk_author;
+ALTER TABLE posts ADD CONSTRAINT fk_author FOREIGN KEY(author_id) REFERENCES users(id) ON DELETE CASCADE;
+
+DROP INDEX IF EXISTS idx_users_deletion_scheduled_at;
+
+ALTER TABLE users DROP COLUMN IF EXISTS deletion_delete_comments;
+ALTER TABLE users DROP COLUMN IF EXISTS deletion_transfer_to;
+ALTER TABLE users DROP COLUMN IF EXISTS deletion_scheduled_at;
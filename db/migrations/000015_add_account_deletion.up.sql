@@ -0,0 +1,25 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deletion_scheduled_at TIMESTAMP WITH TIME ZONE;
+-- deletion_transfer_to sengaja tanpa foreign key; penerima dicek ulang saat purge dan jatuh ke user ghost jika sudah tidak ada
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deletion_transfer_to INT;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deletion_delete_comments BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_users_deletion_scheduled_at ON users(deletion_scheduled_at) WHERE deletion_scheduled_at IS NOT NULL AND deleted_at IS NULL;
+
+-- post dan komentar tidak lagi ikut terhapus bersama user; penghapusan akun memindahkannya terlebih dahulu
+ALTER TABLE posts DROP CONSTRAINT IF EXISTS fk_author;
+ALTER TABLE posts ADD CONSTRAINT fk_author FOREIGN KEY(author_id) REFERENCES users(id) ON DELETE RESTRICT;
+ALTER TABLE comments DROP CONSTRAINT IF EXISTS fk_comment_author;
+ALTER TABLE comments ADD CONSTRAINT fk_comment_author FOREIGN KEY(author_id) REFERENCES users(id) ON DELETE RESTRICT;
+
+-- user ghost menampung post dan komentar dari akun yang dihapus. Username harus sama dengan entity.GhostUsername;
+-- password_hash '!' bukan hash argon2id sehingga akun ini tidak pernah bisa login.
+-- migrasi gagal jika username ghost sudah dipakai user biasa agar post yang dihapus tidak berpindah ke orang lain
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM users WHERE username = 'ghost' AND NOT (email = 'ghost@deleted.invalid' AND password_hash = '!')) THEN
+        RAISE EXCEPTION 'username ghost sudah dipakai user biasa; ganti username user tersebut sebelum menjalankan migrasi ini';
+    END IF;
+    INSERT INTO users (username, email, password_hash, role, display_name)
+    VALUES ('ghost', 'ghost@deleted.invalid', '!', 'author', 'Pengguna terhapus')
+    ON CONFLICT DO NOTHING;
+END $$;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_posts_author_status;
+DROP INDEX IF EXISTS idx_posts_status_published_at;
+
+ALTER TABLE posts DROP COLUMN IF EXISTS status;
+
+DROP TYPE IF EXISTS post_status;
@@ -0,0 +1,14 @@
+-- nilai harus sama persis dengan konstanta PostStatus di internal/entity/post_status.go
+CREATE TYPE post_status AS ENUM ('draft', 'scheduled', 'published', 'archived');
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS status post_status NOT NULL DEFAULT 'draft';
+
+-- post lama diturunkan dari published_at: NULL berarti draft, waktu yang akan datang berarti terjadwal
+UPDATE posts SET status = CASE
+    WHEN published_at IS NULL THEN 'draft'::post_status
+    WHEN published_at <= NOW() THEN 'published'::post_status
+    ELSE 'scheduled'::post_status
+END;
+
+CREATE INDEX IF NOT EXISTS idx_posts_status_published_at ON posts(status, published_at DESC);
+CREATE INDEX IF NOT EXISTS idx_posts_author_status ON posts(author_id, status, updated_at DESC);
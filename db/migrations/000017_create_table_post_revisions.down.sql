@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS trg_post_revisions_immutable ON post_revisions;
+DROP FUNCTION IF EXISTS post_revisions_immutable();
+DROP TABLE IF EXISTS post_revisions;
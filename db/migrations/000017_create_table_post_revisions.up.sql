@@ -0,0 +1,24 @@
+-- categories menyimpan nama kategori saat revisi dibuat agar riwayat tidak berubah jika kategori diganti nama atau dihapus
+CREATE TABLE IF NOT EXISTS post_revisions (
+    id BIGSERIAL PRIMARY KEY,
+    post_id INT NOT NULL,
+    revision INT NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    content TEXT NOT NULL,
+    categories JSONB NOT NULL DEFAULT '[]',
+    editor_id INT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_post_revisions_post FOREIGN KEY(post_id) REFERENCES posts(id) ON DELETE CASCADE,
+    CONSTRAINT uq_post_revisions_post_revision UNIQUE (post_id, revision)
+);
+
+-- revisi tidak boleh diubah; DELETE tetap diizinkan agar revisi ikut terhapus bersama post-nya
+CREATE OR REPLACE FUNCTION post_revisions_immutable() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'post_revisions is immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_post_revisions_immutable
+    BEFORE UPDATE ON post_revisions
+    FOR EACH ROW EXECUTE FUNCTION post_revisions_immutable();
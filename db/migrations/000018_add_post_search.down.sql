@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_posts_search_vector_id;
+DROP INDEX IF EXISTS idx_posts_search_vector_en;
+
+ALTER TABLE posts DROP COLUMN IF EXISTS search_vector_id;
+ALTER TABLE posts DROP COLUMN IF EXISTS search_vector_en;
@@ -0,0 +1,14 @@
+-- satu kolom per konfigurasi bahasa; judul berbobot A sehingga kecocokan di judul diberi peringkat lebih tinggi dari konten.
+-- nama kolom harus sama dengan postSearchConfigs di internal/repository/post_repository.go
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS search_vector_en tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(content, '')), 'B')
+) STORED;
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS search_vector_id tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('indonesian', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('indonesian', coalesce(content, '')), 'B')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_posts_search_vector_en ON posts USING GIN (search_vector_en);
+CREATE INDEX IF NOT EXISTS idx_posts_search_vector_id ON posts USING GIN (search_vector_id);
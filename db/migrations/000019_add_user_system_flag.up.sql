@@ -0,0 +1,15 @@
+-- is_system menandai user milik aplikasi (saat ini hanya ghost) sehingga tidak dicari berdasarkan username saja
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_system BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_system_username ON users(username) WHERE is_system;
+
+-- hanya baris yang dibuat 000015_add_account_deletion.up.sql yang ditandai; password_hash '!' tidak mungkin dimiliki user biasa
+UPDATE users SET is_system = TRUE
+WHERE username = 'ghost' AND email = 'ghost@deleted.invalid' AND password_hash = '!';
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM users WHERE username = 'ghost' AND is_system) THEN
+        RAISE EXCEPTION 'user ghost sistem tidak ditemukan; username ghost kemungkinan dipakai user biasa';
+    END IF;
+END $$;
@@ -21,6 +21,8 @@ const (
 
 	Forbidden Code = "40300"
 
+	TooManyRequests Code = "42900"
+
 	ServerError        Code = "50000"
 	Timeout            Code = "50400"
 	ServiceUnavailable Code = "50300"
@@ -44,6 +46,8 @@ var (
 
 		Forbidden: "Forbidden",
 
+		TooManyRequests: "Too Many Requests",
+
 		ServerError:        "Internal Server Error",
 		Timeout:            "Gateway Timeout",
 		ServiceUnavailable: "Service Unavailable",
@@ -65,6 +69,8 @@ var (
 
 		Forbidden: http.StatusForbidden,
 
+		TooManyRequests: http.StatusTooManyRequests,
+
 		Timeout:            http.StatusGatewayTimeout,
 		ServerError:        http.StatusInternalServerError,
 		ServiceUnavailable: http.StatusServiceUnavailable,
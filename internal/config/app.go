@@ -1,9 +1,17 @@
 package config
 
 import (
+	"time"
+
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/delivery/http"
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/delivery/http/route"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/gateway/mail"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/gateway/messaging"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/gateway/oidc"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/gateway/pwned"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/policy"
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/repository"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/scheduler"
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/usecase"
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
 	"github.com/go-playground/validator/v10"
@@ -15,12 +23,18 @@ import (
 )
 
 type BootstrapConfig struct {
-	DB       *gorm.DB
-	App      *fiber.App
-	Log      *zerolog.Logger
-	Validate *validator.Validate
-	Config   *koanf.Koanf
-	Redis    *redis.Client
+	DB            *gorm.DB
+	App           *fiber.App
+	Log           *zerolog.Logger
+	Validate      *validator.Validate
+	Config        *koanf.Koanf
+	Redis         *redis.Client
+	Mailer        mail.Mailer
+	JWTKeys       *utils.KeySet
+	OIDCProviders map[string]oidc.Provider
+	BreachChecker pwned.Checker
+	// Scheduler menerima job latar belakang; dijalankan dari cmd/web/main.go hanya pada proses utama
+	Scheduler *scheduler.Scheduler
 }
 
 func Boostrap(config *BootstrapConfig) {
@@ -29,30 +43,90 @@ func Boostrap(config *BootstrapConfig) {
 	// Register Repository
 	userRespository := repository.NewUserRepository(config.Log, config.DB)
 	postRepository := repository.NewPostRepository(config.DB)
+	postRevisionRepository := repository.NewPostRevisionRepository(config.DB)
 	categoryRepository := repository.NewCategoryRepository(config.DB)
 	commentRepository := repository.NewCommentRepository(config.DB)
+	tokenRepository := repository.NewTokenRepository(config.Redis)
+	emailVerificationRepository := repository.NewEmailVerificationRepository(config.DB)
+	passwordResetRepository := repository.NewPasswordResetRepository(config.DB)
+	throttleRepository := repository.NewThrottleRepository(config.Redis)
+	apiKeyRepository := repository.NewAPIKeyRepository(config.DB)
+	loginAttemptRepository := repository.NewLoginAttemptRepository(config.Redis)
+	recoveryCodeRepository := repository.NewRecoveryCodeRepository(config.DB)
+	twoFactorChallengeRepository := repository.NewTwoFactorChallengeRepository(config.Redis)
+	settingRepository := repository.NewSettingRepository(config.DB)
+	userIdentityRepository := repository.NewUserIdentityRepository(config.DB)
+	oidcStateRepository := repository.NewOIDCStateRepository(config.Redis)
+	sessionRepository := repository.NewSessionRepository(config.Redis)
+	auditLogRepository := repository.NewAuditLogRepository(config.DB)
+	invitationRepository := repository.NewInvitationRepository(config.DB)
+
+	// Register Policy
+	policyEngine := policy.NewEngine(policy.DefaultRules...)
+
+	// Register Producer
+	postPublishedProducer := messaging.NewPostPublishedProducer(config.Redis, config.Log)
 
 	// Register UseCase
-	userUseCase := usecase.NewUserUseCase(config.DB, config.Log, config.Validate, userRespository, config.Config)
-	postUseCase := usecase.NewPostUseCase(postRepository,categoryRepository, config.Validate)
-	categoryUseCase := usecase.NewCategoryUseCase(categoryRepository, config.Validate)
-	commentUseCase := usecase.NewCommentUseCase(commentRepository,postRepository, config.Validate)
+	auditUseCase := usecase.NewAuditUseCase(config.DB, config.Log, config.Validate, auditLogRepository)
+	accountDeletionUseCase := usecase.NewAccountDeletionUseCase(config.DB, config.Log, config.Validate, auditUseCase, userRespository, postRepository, commentRepository, tokenRepository, sessionRepository, config.Config)
+	userUseCase := usecase.NewUserUseCase(config.DB, config.Log, config.Validate, policyEngine, auditUseCase, accountDeletionUseCase, config.Mailer, config.BreachChecker, userRespository, tokenRepository, emailVerificationRepository, passwordResetRepository, throttleRepository, loginAttemptRepository, recoveryCodeRepository, twoFactorChallengeRepository, settingRepository, userIdentityRepository, oidcStateRepository, sessionRepository, invitationRepository, config.OIDCProviders, config.JWTKeys, config.Config)
+	postUseCase := usecase.NewPostUseCase(config.DB, postRepository, postRevisionRepository, categoryRepository, userRespository, policyEngine, auditUseCase, postPublishedProducer, config.Log, config.Validate)
+	postPublishingUseCase := usecase.NewPostPublishingUseCase(config.DB, config.Log, auditUseCase, postRepository, postPublishedProducer)
+	categoryUseCase := usecase.NewCategoryUseCase(categoryRepository, policyEngine, config.Validate)
+	commentUseCase := usecase.NewCommentUseCase(commentRepository, postRepository, userRespository, policyEngine, auditUseCase, config.Validate)
+	apiKeyUseCase := usecase.NewAPIKeyUseCase(config.Log, config.Validate, apiKeyRepository, userRespository)
+	profileUseCase := usecase.NewProfileUseCase(config.DB, config.Log, config.Validate, userRespository, postRepository)
+	sessionUseCase := usecase.NewSessionUseCase(config.Log, config.Config, sessionRepository, tokenRepository, throttleRepository)
 
 	// Register Controller
 	userController := http.NewUserController(userUseCase, config.Log, config.Redis, config.Validate)
 	postController := http.NewPostController(postUseCase, config.Validate)
 	categoryController := http.NewCategoryController(categoryUseCase, config.Validate)
 	commentController := http.NewCommentController(commentUseCase, config.Validate)
+	apiKeyController := http.NewAPIKeyController(apiKeyUseCase, config.Log, config.Validate)
+	jwksController := http.NewJWKSController(config.JWTKeys)
+	twoFactorController := http.NewTwoFactorController(userUseCase, config.Log, config.Validate)
+	oidcController := http.NewOIDCController(userUseCase, config.Log)
+	sessionController := http.NewSessionController(sessionUseCase, config.Log)
+	auditController := http.NewAuditController(auditUseCase, config.Log)
+	registrationController := http.NewRegistrationController(userUseCase, config.Log, config.Validate)
+	profileController := http.NewProfileController(profileUseCase, config.Log, config.Validate)
+	accountDeletionController := http.NewAccountDeletionController(accountDeletionUseCase, config.Log, config.Validate)
 
 	routeConfig := route.RouteConfig{
-		App:            config.App,
-		Config:         config.Config,
-		UserController: userController,
-		PostController: postController,
-		CategoryController: categoryController,
-		CommentController: commentController,
+		App:                       config.App,
+		Config:                    config.Config,
+		Log:                       config.Log,
+		UserController:            userController,
+		PostController:            postController,
+		CategoryController:        categoryController,
+		CommentController:         commentController,
+		APIKeyController:          apiKeyController,
+		TokenRepository:           tokenRepository,
+		APIKeyUseCase:             apiKeyUseCase,
+		SessionUseCase:            sessionUseCase,
+		JWKSController:            jwksController,
+		TwoFactorController:       twoFactorController,
+		OIDCController:            oidcController,
+		SessionController:         sessionController,
+		ProfileController:         profileController,
+		AuditController:           auditController,
+		RegistrationController:    registrationController,
+		AccountDeletionController: accountDeletionController,
+		JWTKeys:                   config.JWTKeys,
 	}
 
 	routeConfig.Setup()
-}
 
+	// Register Job
+	publishInterval := config.Config.Int("scheduler.publish_interval")
+	if publishInterval <= 0 {
+		publishInterval = 30
+	}
+	config.Scheduler.Add(scheduler.Job{
+		Name:     "publish_scheduled_posts",
+		Interval: time.Duration(publishInterval) * time.Second,
+		Run:      postPublishingUseCase.PublishDue,
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"github.com/bytedance/sonic"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/compress"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/gofiber/fiber/v2/utils"
 	"github.com/knadh/koanf"
 )
 
@@ -19,6 +21,10 @@ func NewFiber(config *koanf.Koanf) *fiber.App {
 	app.Use(compress.New(compress.Config{
 		Level: compress.LevelBestSpeed,
 	}))
+	// UUIDv4 agar request ID tidak membocorkan jumlah request seperti generator default
+	app.Use(requestid.New(requestid.Config{
+		Generator: utils.UUIDv4,
+	}))
 	return app
 }
 
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"os"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
+	"github.com/knadh/koanf"
+	"github.com/rs/zerolog"
+)
+
+// NewJWTKeySet memuat key dari jwt.keys. Key yang hanya memiliki public_key_file tetap diterima saat
+// verifikasi sehingga token lama masih valid selama rotasi. Tanpa jwt.keys, token ditandatangani
+// HS256 dengan jwt.secret seperti sebelumnya dan JWKS akan kosong.
+func NewJWTKeySet(k *koanf.Koanf, log *zerolog.Logger) *utils.KeySet {
+	entries := k.Slices("jwt.keys")
+	if len(entries) == 0 {
+		log.Warn().Msg("jwt.keys is not configured, falling back to HS256 with jwt.secret")
+
+		keySet, err := utils.NewKeySet(utils.LegacyKeyID, utils.NewHMACKey(utils.LegacyKeyID, []byte(k.String("jwt.secret"))))
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to load jwt keys")
+		}
+		return keySet
+	}
+
+	keys := make([]*utils.SigningKey, 0, len(entries))
+	for _, entry := range entries {
+		privatePEM := readKeyFile(log, entry.String("private_key_file"))
+		publicPEM := readKeyFile(log, entry.String("public_key_file"))
+
+		key, err := utils.NewSigningKeyFromPEM(entry.String("kid"), entry.String("algorithm"), privatePEM, publicPEM)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to load jwt keys")
+		}
+		keys = append(keys, key)
+	}
+
+	keySet, err := utils.NewKeySet(k.String("jwt.active_kid"), keys...)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to load jwt keys")
+	}
+	return keySet
+}
+
+func readKeyFile(log *zerolog.Logger, path string) []byte {
+	if path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatal().Err(err).Msgf("failed to read jwt key file %s", path)
+	}
+	return data
+}
@@ -0,0 +1,22 @@
+package config
+
+import (
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/gateway/mail"
+	"github.com/knadh/koanf"
+	"github.com/rs/zerolog"
+)
+
+func NewMailer(k *koanf.Koanf, log *zerolog.Logger) mail.Mailer {
+	port := k.Int("mail.port")
+	if port == 0 {
+		port = 587
+	}
+
+	return mail.NewMailer(mail.Config{
+		Host:     k.String("mail.host"),
+		Port:     port,
+		Username: k.String("mail.username"),
+		Password: k.String("mail.password"),
+		From:     k.String("mail.from"),
+	}, log)
+}
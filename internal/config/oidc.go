@@ -0,0 +1,40 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/gateway/oidc"
+	"github.com/knadh/koanf"
+	"github.com/rs/zerolog"
+)
+
+// NewOIDCProviders membuat satu provider untuk setiap entri di oidc.providers, dengan nama entri sebagai
+// segmen URL /api/v1/auth/oidc/:provider. Discovery baru dilakukan saat login pertama agar aplikasi tetap
+// dapat berjalan walaupun issuer sedang tidak dapat dihubungi.
+func NewOIDCProviders(k *koanf.Koanf, log *zerolog.Logger) map[string]oidc.Provider {
+	providers := make(map[string]oidc.Provider)
+	for _, name := range k.MapKeys("oidc.providers") {
+		prefix := "oidc.providers." + name + "."
+
+		issuer := k.String(prefix + "issuer")
+		clientID := k.String(prefix + "client_id")
+		if issuer == "" || clientID == "" {
+			log.Fatal().Msgf("oidc provider %s requires issuer and client_id", name)
+		}
+
+		redirectURL := k.String(prefix + "redirect_url")
+		if redirectURL == "" {
+			redirectURL = strings.TrimRight(k.String("app.url"), "/") + "/api/v1/auth/oidc/" + name + "/callback"
+		}
+
+		providers[name] = oidc.NewProvider(oidc.Config{
+			Name:         name,
+			IssuerURL:    issuer,
+			ClientID:     clientID,
+			ClientSecret: k.String(prefix + "client_secret"),
+			RedirectURL:  redirectURL,
+			Scopes:       k.Strings(prefix + "scopes"),
+		}, nil)
+	}
+	return providers
+}
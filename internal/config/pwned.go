@@ -0,0 +1,23 @@
+package config
+
+import (
+	"os"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/gateway/pwned"
+	"github.com/knadh/koanf"
+	"github.com/rs/zerolog"
+)
+
+func NewBreachedPasswordChecker(k *koanf.Koanf, log *zerolog.Logger) pwned.Checker {
+	directory := k.String("password_policy.breached.directory")
+	if directory != "" {
+		if info, err := os.Stat(directory); err != nil || !info.IsDir() {
+			log.Fatal().Msgf("password_policy.breached.directory %s is not a readable directory", directory)
+		}
+	}
+
+	return pwned.NewChecker(pwned.Config{
+		Directory: directory,
+		MinCount:  k.Int("password_policy.breached.min_count"),
+	})
+}
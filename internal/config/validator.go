@@ -1,10 +1,24 @@
 package config
 
 import (
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
 	"github.com/go-playground/validator/v10"
 	"github.com/knadh/koanf"
 )
 
 func NewValidator(k *koanf.Koanf) *validator.Validate {
-	return validator.New()
+	validate := validator.New()
+
+	validate.RegisterValidation("user_role", func(fl validator.FieldLevel) bool {
+		return entity.UserRole(fl.Field().String()).IsValid()
+	})
+	validate.RegisterValidation("api_key_scope", func(fl validator.FieldLevel) bool {
+		return entity.IsValidAPIKeyScope(fl.Field().String())
+	})
+
+	// optional_http_url menerima string kosong (untuk menghapus nilai) atau URL http/https yang valid.
+	// omitempty saja tidak cukup karena pada *string ia hanya melewati pointer nil, bukan string kosong.
+	validate.RegisterAlias("optional_http_url", "eq=|http_url")
+
+	return validate
 }
@@ -0,0 +1,65 @@
+package http
+
+import (
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/common/response"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/delivery/http/middleware"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/usecase"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+)
+
+type AccountDeletionController struct {
+	Log                    *zerolog.Logger
+	accountDeletionUseCase usecase.AccountDeletionUseCase
+	validator              *validator.Validate
+}
+
+func NewAccountDeletionController(accountDeletionUseCase usecase.AccountDeletionUseCase, log *zerolog.Logger, validator *validator.Validate) *AccountDeletionController {
+	return &AccountDeletionController{
+		Log:                    log,
+		accountDeletionUseCase: accountDeletionUseCase,
+		validator:              validator,
+	}
+}
+
+func (c *AccountDeletionController) Get(ctx *fiber.Ctx) error {
+	data, err := c.accountDeletionUseCase.Get(middleware.GetUser(ctx))
+	if err != nil {
+		return err
+	}
+
+	return utils.SendSuccessResponse(ctx, response.Success, data)
+}
+
+// Schedule menjadwalkan penghapusan akun sendiri setelah masa tenggang; selama itu akun tetap dapat dipakai dan dibatalkan
+func (c *AccountDeletionController) Schedule(ctx *fiber.Ctx) error {
+	request := new(model.AccountDeletionRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Error().Msgf("Failed to parse request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	if err := c.validator.Struct(request); err != nil {
+		return utils.SendValidatorErrorResponse(ctx, err)
+	}
+
+	data, err := c.accountDeletionUseCase.Schedule(ctx.Context(), middleware.GetUser(ctx), request)
+	if err != nil {
+		c.Log.Warn().Msgf("Failed to schedule account deletion: %v", err)
+		return err
+	}
+
+	return utils.SendSuccessResponse(ctx, response.Success, data)
+}
+
+func (c *AccountDeletionController) Cancel(ctx *fiber.Ctx) error {
+	if err := c.accountDeletionUseCase.Cancel(middleware.GetUser(ctx)); err != nil {
+		c.Log.Warn().Msgf("Failed to cancel account deletion: %v", err)
+		return err
+	}
+
+	return utils.SendSuccessResponse(ctx, response.Success)
+}
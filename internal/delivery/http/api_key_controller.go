@@ -0,0 +1,74 @@
+package http
+
+import (
+	"strconv"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/common/response"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/delivery/http/middleware"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/usecase"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+)
+
+type APIKeyController struct {
+	Log           *zerolog.Logger
+	apiKeyUseCase usecase.APIKeyUseCase
+	validator     *validator.Validate
+}
+
+func NewAPIKeyController(apiKeyUseCase usecase.APIKeyUseCase, log *zerolog.Logger, validator *validator.Validate) *APIKeyController {
+	return &APIKeyController{
+		Log:           log,
+		apiKeyUseCase: apiKeyUseCase,
+		validator:     validator,
+	}
+}
+
+func (c *APIKeyController) Create(ctx *fiber.Ctx) error {
+	request := new(model.CreateAPIKeyRequest)
+
+	err := ctx.BodyParser(request)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to parse request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	if err := c.validator.Struct(request); err != nil {
+		return utils.SendValidatorErrorResponse(ctx, err)
+	}
+
+	data, err := c.apiKeyUseCase.Create(ctx.Context(), middleware.GetUser(ctx), request)
+	if err != nil {
+		c.Log.Warn().Msgf("Failed to create api key: %v", err)
+		return err
+	}
+
+	return utils.SendSuccessResponse(ctx, response.Created, data)
+}
+
+func (c *APIKeyController) List(ctx *fiber.Ctx) error {
+	data, err := c.apiKeyUseCase.List(ctx.Context(), middleware.GetUser(ctx))
+	if err != nil {
+		c.Log.Warn().Msgf("Failed to list api keys: %v", err)
+		return err
+	}
+
+	return utils.SendSuccessResponse(ctx, response.Success, data)
+}
+
+func (c *APIKeyController) Revoke(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 32)
+	if err != nil {
+		return utils.SendErrorResponse(ctx, response.BadRequest, "ID API key tidak valid")
+	}
+
+	if err := c.apiKeyUseCase.Revoke(ctx.Context(), middleware.GetUser(ctx), uint(id)); err != nil {
+		c.Log.Warn().Msgf("Failed to revoke api key: %v", err)
+		return err
+	}
+
+	return utils.SendSuccessResponse(ctx, response.Success)
+}
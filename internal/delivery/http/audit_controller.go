@@ -0,0 +1,46 @@
+package http
+
+import (
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/common/response"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/usecase"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+)
+
+type AuditController struct {
+	Log          *zerolog.Logger
+	auditUseCase usecase.AuditUseCase
+}
+
+func NewAuditController(auditUseCase usecase.AuditUseCase, log *zerolog.Logger) *AuditController {
+	return &AuditController{
+		Log:          log,
+		auditUseCase: auditUseCase,
+	}
+}
+
+// List mendukung filter actor_id, action, target_type, target_id, serta rentang from/to (RFC 3339)
+func (c *AuditController) List(ctx *fiber.Ctx) error {
+	request := new(model.ListAuditLogRequest)
+	if err := ctx.QueryParser(request); err != nil {
+		c.Log.Warn().Msgf("Failed to parse audit log filter: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	if request.Page < 1 {
+		request.Page = 1
+	}
+	if request.Limit < 1 || request.Limit > 100 {
+		request.Limit = 20
+	}
+
+	data, err := c.auditUseCase.List(request)
+	if err != nil {
+		c.Log.Warn().Msgf("Failed to list audit logs: %v", err)
+		return err
+	}
+
+	return utils.SendSuccessResponse(ctx, response.Success, data)
+}
@@ -6,6 +6,8 @@ import (
 	"strconv"
 
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/common/response"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/delivery/http/middleware"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model/converter"
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/usecase"
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
 	"github.com/go-playground/validator/v10"
@@ -36,15 +38,18 @@ func (h *CategoryController) CreateCategory(c *fiber.Ctx) error {
 		return utils.SendValidatorErrorResponse(c, err)
 	}
 
-	category, err := h.categoryService.CreateCategory(req.Name)
+	category, err := h.categoryService.CreateCategory(req.Name, middleware.GetUser(c))
 	if err != nil {
+		if utils.IsErrForbidden(err) {
+			return utils.SendErrorResponse(c, response.Forbidden, err.Error())
+		}
 		if errors.Is(err, utils.ErrValidation("")) {
 			return utils.SendErrorResponse(c, response.BadRequest, err.Error())
 		}
 		return utils.SendErrorResponse(c, response.ServerError, err.Error())
 	}
 
-	return utils.SendSuccessResponse(c, response.Success, "Kategori berhasil dibuat", category)
+	return utils.SendSuccessResponse(c, response.Success, converter.CategoryToResponse(category))
 }
 
 func (h *CategoryController) GetAllCategories(c *fiber.Ctx) error {
@@ -53,7 +58,7 @@ func (h *CategoryController) GetAllCategories(c *fiber.Ctx) error {
 		return utils.SendErrorResponse(c, response.ServerError, err.Error())
 	}
 
-	return utils.SendSuccessResponse(c, response.Success, categories)
+	return utils.SendSuccessResponse(c, response.Success, converter.CategoriesToResponses(categories))
 }
 
 func (h *CategoryController) GetCategoryByID(c *fiber.Ctx) error {
@@ -70,7 +75,7 @@ func (h *CategoryController) GetCategoryByID(c *fiber.Ctx) error {
 		return utils.SendErrorResponse(c, response.ServerError, err.Error())
 	}
 
-	return utils.SendSuccessResponse(c, response.Success, category)
+	return utils.SendSuccessResponse(c, response.Success, converter.CategoryToResponse(category))
 }
 
 // UpdateCategoryRequest merepresentasikan payload request untuk update kategori
@@ -94,8 +99,11 @@ func (h *CategoryController) UpdateCategory(c *fiber.Ctx) error {
 		return utils.SendValidatorErrorResponse(c, err)
 	}
 
-	category, err := h.categoryService.UpdateCategory(uint(id), req.Name)
+	category, err := h.categoryService.UpdateCategory(uint(id), req.Name, middleware.GetUser(c))
 	if err != nil {
+		if utils.IsErrForbidden(err) {
+			return utils.SendErrorResponse(c, response.Forbidden, err.Error())
+		}
 		if errors.Is(err, utils.ErrNotFound("")) {
 			return utils.SendErrorResponse(c, response.InvalidRequest, err.Error())
 		}
@@ -105,7 +113,7 @@ func (h *CategoryController) UpdateCategory(c *fiber.Ctx) error {
 		return utils.SendErrorResponse(c, response.ServerError, err.Error())
 	}
 
-	return utils.SendSuccessResponse(c, response.Success, category)
+	return utils.SendSuccessResponse(c, response.Success, converter.CategoryToResponse(category))
 }
 
 // DeleteCategory menghapus kategori
@@ -115,8 +123,11 @@ func (h *CategoryController) DeleteCategory(c *fiber.Ctx) error {
 		return utils.SendErrorResponse(c, response.BadRequest, "ID kategori tidak valid")
 	}
 
-	err = h.categoryService.DeleteCategory(uint(id))
+	err = h.categoryService.DeleteCategory(uint(id), middleware.GetUser(c))
 	if err != nil {
+		if utils.IsErrForbidden(err) {
+			return utils.SendErrorResponse(c, response.Forbidden, err.Error())
+		}
 		if errors.Is(err, utils.ErrNotFound("")) {
 			return utils.SendErrorResponse(c, response.InvalidRequest, err.Error())
 		}
@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/common/response"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/delivery/http/middleware"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model/converter"
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/usecase"
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
 	"github.com/go-playground/validator/v10"
@@ -40,10 +42,13 @@ func (h *CommentController) CreateComment(c *fiber.Ctx) error {
 		return utils.SendValidatorErrorResponse(c, err)
 	}
 
-	authorID := c.Locals("userID").(uint)
+	auth := middleware.GetUser(c)
 
-	comment, err := h.newCommentUseCase.CreateComment(req.Content, uint(postID), authorID)
+	comment, err := h.newCommentUseCase.CreateComment(req.Content, uint(postID), auth)
 	if err != nil {
+		if utils.IsErrForbidden(err) {
+			return utils.SendErrorResponse(c, response.Forbidden, err.Error())
+		}
 		if errors.Is(err, utils.ErrNotFound("")) {
 			return utils.SendErrorResponse(c, response.BadRequest, err.Error())
 		}
@@ -53,7 +58,7 @@ func (h *CommentController) CreateComment(c *fiber.Ctx) error {
 		return utils.SendErrorResponse(c, response.ServerError, err.Error())
 	}
 
-	return utils.SendSuccessResponse(c, response.Success, comment)
+	return utils.SendSuccessResponse(c, response.Success, converter.CommentToResponse(comment))
 }
 
 func (h *CommentController) GetCommentsByPostID(c *fiber.Ctx) error {
@@ -70,7 +75,7 @@ func (h *CommentController) GetCommentsByPostID(c *fiber.Ctx) error {
 		return utils.SendErrorResponse(c, response.ServerError, err.Error())
 	}
 
-	return utils.SendSuccessResponse(c, response.Success, comments)
+	return utils.SendSuccessResponse(c, response.Success, converter.CommentsToResponses(comments))
 }
 
 type UpdateCommentRequest struct {
@@ -92,15 +97,15 @@ func (h *CommentController) UpdateComment(c *fiber.Ctx) error {
 		return utils.SendValidatorErrorResponse(c, err)
 	}
 
-	authorID := c.Locals("userID").(uint)
+	auth := middleware.GetUser(c)
 
-	comment, err := h.newCommentUseCase.UpdateComment(uint(commentID), authorID, req.Content)
+	comment, err := h.newCommentUseCase.UpdateComment(uint(commentID), auth, req.Content)
 	if err != nil {
 		if errors.Is(err, utils.ErrNotFound("")) {
 			return utils.SendErrorResponse(c, response.BadRequest, err.Error())
 		}
-		if errors.Is(err, utils.ErrForbidden("")) {
-			return utils.SendErrorResponse(c, response.BadRequest, err.Error())
+		if utils.IsErrForbidden(err) {
+			return utils.SendErrorResponse(c, response.Forbidden, err.Error())
 		}
 		if strings.Contains(err.Error(), "tidak valid") { // Untuk validasi dari service
 			return utils.SendErrorResponse(c, response.BadRequest, err.Error())
@@ -108,7 +113,7 @@ func (h *CommentController) UpdateComment(c *fiber.Ctx) error {
 		return utils.SendErrorResponse(c, response.ServerError, err.Error())
 	}
 
-	return utils.SendSuccessResponse(c, response.Success, comment)
+	return utils.SendSuccessResponse(c, response.Success, converter.CommentToResponse(comment))
 }
 
 // DeleteComment menghapus komentar
@@ -118,15 +123,15 @@ func (h *CommentController) DeleteComment(c *fiber.Ctx) error {
 		return utils.SendErrorResponse(c, response.BadRequest, "ID komentar tidak valid")
 	}
 
-	authorID := c.Locals("userID").(uint)
+	auth := middleware.GetUser(c)
 
-	err = h.newCommentUseCase.DeleteComment(uint(commentID), authorID)
+	err = h.newCommentUseCase.DeleteComment(uint(commentID), auth)
 	if err != nil {
 		if errors.Is(err, utils.ErrNotFound("")) {
 			return utils.SendErrorResponse(c, response.BadRequest, err.Error())
 		}
-		if errors.Is(err, utils.ErrForbidden("")) {
-			return utils.SendErrorResponse(c, response.BadRequest, err.Error())
+		if utils.IsErrForbidden(err) {
+			return utils.SendErrorResponse(c, response.Forbidden, err.Error())
 		}
 		return utils.SendErrorResponse(c, response.ServerError, err.Error())
 	}
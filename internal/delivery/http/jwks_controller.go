@@ -0,0 +1,20 @@
+package http
+
+import (
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type JWKSController struct {
+	keys *utils.KeySet
+}
+
+func NewJWKSController(keys *utils.KeySet) *JWKSController {
+	return &JWKSController{keys: keys}
+}
+
+// GetJWKS tidak dibungkus WebResponse karena formatnya ditentukan oleh RFC 7517 dan dibaca langsung oleh library JWT
+func (c *JWKSController) GetJWKS(ctx *fiber.Ctx) error {
+	ctx.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return ctx.JSON(c.keys.JWKS())
+}
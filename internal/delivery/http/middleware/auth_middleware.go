@@ -1,19 +1,28 @@
 package middleware
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/common/response"
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/repository"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/usecase"
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
 	"github.com/gofiber/fiber/v2"
-	"github.com/knadh/koanf"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
 )
 
-func JWTMiddleware(k *koanf.Koanf) fiber.Handler {
+// JWTMiddleware menerima access token JWT maupun API key (header "Authorization: Bearer blog_..."
+// atau "X-API-Key") dan mengisi locals userID, userRole, dan auth yang sama untuk keduanya.
+func JWTMiddleware(jwtKeys *utils.KeySet, tokenRepository repository.TokenRepository, apiKeyUseCase usecase.APIKeyUseCase, sessionUseCase usecase.SessionUseCase) fiber.Handler {
 	return func(c *fiber.Ctx) error {
+		if apiKey := c.Get("X-API-Key"); apiKey != "" {
+			return authenticateAPIKey(c, apiKeyUseCase, apiKey)
+		}
+
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
 			return utils.SendErrorResponse(c, response.TokenNotFound)
@@ -25,7 +34,11 @@ func JWTMiddleware(k *koanf.Koanf) fiber.Handler {
 		}
 
 		tokenString := parts[1]
-		claims, err := utils.ParseToken(tokenString, k.String("jwt.secret"))
+		if strings.HasPrefix(tokenString, usecase.APIKeyPrefix) {
+			return authenticateAPIKey(c, apiKeyUseCase, tokenString)
+		}
+
+		claims, err := utils.ParseToken(tokenString, jwtKeys)
 		if err != nil {
 			fmt.Println(err)
 			return utils.SendErrorResponse(c, response.Unauthorized)
@@ -35,13 +48,77 @@ func JWTMiddleware(k *koanf.Koanf) fiber.Handler {
 		if err != nil {
 			return utils.SendErrorResponse(c, response.ServerError)
 		}
+
+		revoked, err := tokenRepository.IsAccessTokenRevoked(c.Context(), claims.ID, claims.SessionID, uint(userId), claims.Version)
+		if err != nil {
+			return utils.SendErrorResponse(c, response.ServerError)
+		}
+		if revoked {
+			return utils.SendErrorResponse(c, response.InvalidToken)
+		}
+
+		// token lama tanpa sid tidak terikat ke sesi; kegagalan selain sesi hilang tidak boleh memblokir request
+		if claims.SessionID != "" {
+			err := sessionUseCase.Touch(c.Context(), claims.SessionID, c.IP())
+			if errors.Is(err, repository.ErrSessionNotFound) {
+				return utils.SendErrorResponse(c, response.InvalidToken)
+			}
+		}
+
 		c.Locals("userID", uint(userId))
 		c.Locals("userRole", claims.Role)
+		c.Locals("claims", claims)
+		c.Locals("auth", withRequestInfo(c, &model.Auth{ID: uint(userId), Role: claims.Role, ImpersonatorID: claims.ImpersonatorID()}))
 
 		return c.Next()
 	}
 }
 
+func authenticateAPIKey(c *fiber.Ctx, apiKeyUseCase usecase.APIKeyUseCase, key string) error {
+	auth, err := apiKeyUseCase.Authenticate(c.Context(), key)
+	if err != nil {
+		if errors.Is(err, fiber.ErrUnauthorized) {
+			return utils.SendErrorResponse(c, response.InvalidToken)
+		}
+		return utils.SendErrorResponse(c, response.ServerError)
+	}
+
+	c.Locals("userID", auth.ID)
+	c.Locals("userRole", auth.Role)
+	c.Locals("auth", withRequestInfo(c, auth))
+
+	return c.Next()
+}
+
+// withRequestInfo melengkapi auth dengan IP dan request ID dari middleware requestid untuk audit log
+func withRequestInfo(c *fiber.Ctx, auth *model.Auth) *model.Auth {
+	auth.IP = c.IP()
+	auth.RequestID, _ = c.Locals(requestid.ConfigDefault.ContextKey).(string)
+	return auth
+}
+
+// RequireScope membatasi request API key pada scope tertentu; access token JWT selalu lolos
+func RequireScope(scope string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		auth := GetUser(c)
+		if auth == nil || !auth.HasScope(scope) {
+			return utils.SendErrorResponse(c, response.Forbidden, "API key tidak memiliki scope "+scope)
+		}
+		return c.Next()
+	}
+}
+
+// DenyAPIKey menolak request yang diautentikasi dengan API key, misalnya untuk mengelola API key itu sendiri
+func DenyAPIKey() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		auth := GetUser(c)
+		if auth == nil || auth.APIKeyID != 0 {
+			return utils.SendErrorResponse(c, response.Forbidden, "Endpoint ini tidak dapat diakses dengan API key")
+		}
+		return c.Next()
+	}
+}
+
 func GetUser(ctx *fiber.Ctx) *model.Auth {
 	auth, _ := ctx.Locals("auth").(*model.Auth)
 	return auth
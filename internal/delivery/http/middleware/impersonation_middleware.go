@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"path"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/common/response"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ImpersonationGuard dipasang setelah JWTMiddleware. Setiap request dengan token impersonation dicatat di log
+// dan diberi atribut di span tracing. Hanya request baca (GET, HEAD, OPTIONS) yang diteruskan; request lain yang
+// mengubah data ditolak kecuali "METHOD /path" cocok dengan salah satu pola allowed (sintaks path.Match,
+// mis. "PUT /api/v1/comments/*").
+func ImpersonationGuard(log *zerolog.Logger, allowed []string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		auth := GetUser(c)
+		if auth == nil || auth.ImpersonatorID == 0 {
+			return c.Next()
+		}
+
+		trace.SpanFromContext(c.UserContext()).SetAttributes(
+			attribute.Bool("auth.impersonated", true),
+			attribute.Int64("auth.impersonator_id", int64(auth.ImpersonatorID)),
+			attribute.Int64("auth.user_id", int64(auth.ID)),
+		)
+		log.Info().
+			Bool("impersonated", true).
+			Uint("impersonator_id", auth.ImpersonatorID).
+			Uint("user_id", auth.ID).
+			Str("method", c.Method()).
+			Str("path", c.Path()).
+			Str("request_id", auth.RequestID).
+			Msg("Impersonated request")
+
+		if !isReadOnlyMethod(c.Method()) && !matchRoute(allowed, c.Method()+" "+c.Path()) {
+			log.Warn().Uint("impersonator_id", auth.ImpersonatorID).Msgf("Blocked destructive impersonated request %s %s", c.Method(), c.Path())
+			return utils.SendErrorResponse(c, response.Forbidden, "Aksi ini tidak diizinkan selama impersonation")
+		}
+		return c.Next()
+	}
+}
+
+// DenyImpersonation menolak token impersonation pada endpoint yang mengubah keamanan akun, apa pun metodenya
+func DenyImpersonation() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if auth := GetUser(c); auth != nil && auth.ImpersonatorID != 0 {
+			return utils.SendErrorResponse(c, response.Forbidden, "Endpoint ini tidak dapat diakses selama impersonation")
+		}
+		return c.Next()
+	}
+}
+
+func isReadOnlyMethod(method string) bool {
+	return method == fiber.MethodGet || method == fiber.MethodHead || method == fiber.MethodOptions
+}
+
+func matchRoute(patterns []string, route string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, route); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"strconv"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/common/response"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequireRole hanya meneruskan request jika role user (dari JWTMiddleware) termasuk salah satu roles
+func RequireRole(roles ...entity.UserRole) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !hasRole(c, roles) {
+			return utils.SendErrorResponse(c, response.Forbidden)
+		}
+		return c.Next()
+	}
+}
+
+// RequireSelfOrRole meneruskan request jika parameter param sama dengan ID user yang login,
+// atau jika user memiliki salah satu roles
+func RequireSelfOrRole(param string, roles ...entity.UserRole) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if hasRole(c, roles) {
+			return c.Next()
+		}
+
+		id, err := strconv.ParseUint(c.Params(param), 10, 32)
+		if err != nil {
+			return utils.SendErrorResponse(c, response.BadRequest)
+		}
+
+		userID, _ := c.Locals("userID").(uint)
+		if uint(id) != userID {
+			return utils.SendErrorResponse(c, response.Forbidden)
+		}
+		return c.Next()
+	}
+}
+
+func hasRole(c *fiber.Ctx, roles []entity.UserRole) bool {
+	userRole, _ := c.Locals("userRole").(string)
+	for _, role := range roles {
+		if entity.UserRole(userRole) == role {
+			return true
+		}
+	}
+	return false
+}
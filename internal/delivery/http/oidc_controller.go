@@ -0,0 +1,61 @@
+package http
+
+import (
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/common/response"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/usecase"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+)
+
+type OIDCController struct {
+	Log         *zerolog.Logger
+	userUseCase usecase.UserUseCase
+}
+
+func NewOIDCController(userUseCase usecase.UserUseCase, log *zerolog.Logger) *OIDCController {
+	return &OIDCController{
+		Log:         log,
+		userUseCase: userUseCase,
+	}
+}
+
+// Login mengarahkan browser ke halaman login provider
+func (c *OIDCController) Login(ctx *fiber.Ctx) error {
+	authURL, err := c.userUseCase.OIDCAuthorizationURL(ctx.Context(), ctx.Params("provider"))
+	if err != nil {
+		if utils.IsErrNotFound(err) {
+			return utils.SendErrorResponse(ctx, response.ResourceNotFound, err.Error())
+		}
+		return err
+	}
+
+	return ctx.Redirect(authURL, fiber.StatusFound)
+}
+
+// Callback menerima redirect dari provider dan mengembalikan token dengan format yang sama seperti /auth/login
+func (c *OIDCController) Callback(ctx *fiber.Ctx) error {
+	if providerError := ctx.Query("error"); providerError != "" {
+		c.Log.Warn().Msgf("OIDC provider returned error: %s %s", providerError, ctx.Query("error_description"))
+		return utils.SendErrorResponse(ctx, response.Unauthorized, providerError)
+	}
+
+	request := &model.OIDCCallbackRequest{
+		Provider:   ctx.Params("provider"),
+		Code:       ctx.Query("code"),
+		State:      ctx.Query("state"),
+		ClientInfo: clientInfo(ctx),
+	}
+
+	data, err := c.userUseCase.OIDCCallback(ctx.Context(), request)
+	if err != nil {
+		c.Log.Warn().Msgf("Failed to login with OIDC: %v", err)
+		if utils.IsErrForbidden(err) {
+			return utils.SendErrorResponse(ctx, response.Forbidden, err.Error())
+		}
+		return err
+	}
+
+	return utils.SendSuccessResponse(ctx, response.Success, data)
+}
@@ -6,7 +6,9 @@ import (
 	"strings"
 
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/common/response"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/delivery/http/middleware"
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model/converter"
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/usecase"
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
 	"github.com/go-playground/validator/v10"
@@ -35,10 +37,13 @@ func (c *PostController) CreatePost(ctx *fiber.Ctx) error {
 		return utils.SendValidatorErrorResponse(ctx, err)
 	}
 
-	authorID := ctx.Locals("userID").(uint)
+	auth := middleware.GetUser(ctx)
 
-	post, err := c.postUseCase.CreatePost(request.Title, request.Content, authorID, request.CategoryNames)
+	post, err := c.postUseCase.CreatePost(request.Title, request.Content, auth, request.CategoryNames)
 	if err != nil {
+		if utils.IsErrForbidden(err) {
+			return utils.SendErrorResponse(ctx, response.Forbidden, err.Error())
+		}
 		if strings.Contains(err.Error(), "Invalid") || strings.Contains(err.Error(), "has already been taken") {
 			return utils.SendErrorResponse(ctx, response.BadRequest, err.Error())
 		}
@@ -49,7 +54,7 @@ func (c *PostController) CreatePost(ctx *fiber.Ctx) error {
 		return utils.SendErrorResponse(ctx, response.ServerError, err.Error())
 	}
 
-	return utils.SendSuccessResponse(ctx, response.Success, post)
+	return utils.SendSuccessResponse(ctx, response.Success, converter.PostToResponse(post))
 }
 
 func (h *PostController) GetPostByID(c *fiber.Ctx) error {
@@ -66,7 +71,7 @@ func (h *PostController) GetPostByID(c *fiber.Ctx) error {
 		return utils.SendErrorResponse(c, response.ServerError, err.Error())
 	}
 
-	return utils.SendSuccessResponse(c, response.Success, post)
+	return utils.SendSuccessResponse(c, response.Success, converter.PostToResponse(post))
 }
 
 func (h *PostController) GetPostBySlug(c *fiber.Ctx) error {
@@ -83,26 +88,59 @@ func (h *PostController) GetPostBySlug(c *fiber.Ctx) error {
 		return utils.SendErrorResponse(c, response.ServerError, err.Error())
 	}
 
-	return utils.SendSuccessResponse(c, response.Success, post)
+	return utils.SendSuccessResponse(c, response.Success, converter.PostToResponse(post))
 }
 
+// GetAllPosts melayani daftar post publik dan /admin/posts; lihat model.ListPostRequest untuk filter dan sort
 func (h *PostController) GetAllPosts(c *fiber.Ctx) error {
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	var req model.ListPostRequest
+	if err := c.QueryParser(&req); err != nil {
+		return utils.SendErrorResponse(c, response.BadRequest)
+	}
 
-	if page < 1 {
-		page = 1
+	if err := h.validator.Struct(req); err != nil {
+		return utils.SendValidatorErrorResponse(c, err)
 	}
-	if limit < 1 || limit > 100 {
-		limit = 10
+
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.Limit < 1 || req.Limit > 100 {
+		req.Limit = 10
 	}
 
-	posts, err := h.postUseCase.GetAllPosts(page, limit)
+	data, err := h.postUseCase.GetAllPosts(&req, middleware.GetUser(c))
+	if err != nil {
+		return h.sendStatusError(c, err)
+	}
+
+	return utils.SendSuccessResponse(c, response.Success, data)
+}
+
+// SearchPosts melayani GET /posts/search?q=...&lang=en|id, hasil diurutkan dari yang paling relevan
+func (h *PostController) SearchPosts(c *fiber.Ctx) error {
+	var req model.SearchPostRequest
+	if err := c.QueryParser(&req); err != nil {
+		return utils.SendErrorResponse(c, response.BadRequest)
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		return utils.SendValidatorErrorResponse(c, err)
+	}
+
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.Limit < 1 || req.Limit > 100 {
+		req.Limit = 10
+	}
+
+	data, err := h.postUseCase.SearchPosts(&req)
 	if err != nil {
 		return utils.SendErrorResponse(c, response.ServerError, err.Error())
 	}
 
-	return utils.SendSuccessResponse(c, response.Success, posts)
+	return utils.SendSuccessResponse(c, response.Success, data)
 }
 
 func (h *PostController) UpdatePost(c *fiber.Ctx) error {
@@ -120,15 +158,15 @@ func (h *PostController) UpdatePost(c *fiber.Ctx) error {
 		return utils.SendValidatorErrorResponse(c, err)
 	}
 
-	authorID := c.Locals("userID").(uint)
+	auth := middleware.GetUser(c)
 
-	post, err := h.postUseCase.UpdatePost(uint(id), req.Title, req.Content, req.PublishedAt, req.CategoryNames, authorID) // Tambahkan CategoryNames
+	post, err := h.postUseCase.UpdatePost(uint(id), req.Title, req.Content, req.CategoryNames, auth) // Tambahkan CategoryNames
 	if err != nil {
 		if errors.Is(err, utils.ErrNotFound("")) {
 			return utils.SendErrorResponse(c, response.ServerError, err.Error())
 		}
-		if errors.Is(err, utils.ErrForbidden("")) {
-			return utils.SendErrorResponse(c, response.BadRequest, err.Error())
+		if utils.IsErrForbidden(err) {
+			return utils.SendErrorResponse(c, response.Forbidden, err.Error())
 		}
 		if strings.Contains(err.Error(), "tidak valid") || strings.Contains(err.Error(), "sudah terpakai") {
 			return utils.SendErrorResponse(c, response.BadRequest, err.Error())
@@ -136,7 +174,7 @@ func (h *PostController) UpdatePost(c *fiber.Ctx) error {
 		return utils.SendErrorResponse(c, response.ServerError, err.Error())
 	}
 
-	return utils.SendSuccessResponse(c, response.Success, post)
+	return utils.SendSuccessResponse(c, response.Success, converter.PostToResponse(post))
 }
 
 func (h *PostController) DeletePost(c *fiber.Ctx) error {
@@ -145,18 +183,159 @@ func (h *PostController) DeletePost(c *fiber.Ctx) error {
 		return utils.SendErrorResponse(c, response.BadRequest, "ID postingan tidak valid")
 	}
 
-	authorID := c.Locals("userID").(uint)
+	auth := middleware.GetUser(c)
 
-	err = h.postUseCase.DeletePost(uint(id), authorID)
+	err = h.postUseCase.DeletePost(uint(id), auth)
 	if err != nil {
 		if errors.Is(err, utils.ErrNotFound("")) {
 			return utils.SendErrorResponse(c, response.ServerError, err.Error())
 		}
-		if errors.Is(err, utils.ErrForbidden("")) {
-			return utils.SendErrorResponse(c, response.BadRequest, err.Error())
+		if utils.IsErrForbidden(err) {
+			return utils.SendErrorResponse(c, response.Forbidden, err.Error())
 		}
 		return utils.SendErrorResponse(c, response.ServerError, err.Error())
 	}
 
 	return utils.SendSuccessResponse(c, response.Success)
 }
+
+// PublishPost menerbitkan post; body {"publishAt": ...} opsional untuk menjadwalkan penerbitan
+func (h *PostController) PublishPost(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.SendErrorResponse(c, response.BadRequest, "ID postingan tidak valid")
+	}
+
+	var req model.PublishPostRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.SendErrorResponse(c, response.BadRequest)
+		}
+	}
+
+	post, err := h.postUseCase.PublishPost(uint(id), req.PublishAt, middleware.GetUser(c))
+	if err != nil {
+		return h.sendStatusError(c, err)
+	}
+
+	return utils.SendSuccessResponse(c, response.Success, converter.PostToResponse(post))
+}
+
+func (h *PostController) UnpublishPost(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.SendErrorResponse(c, response.BadRequest, "ID postingan tidak valid")
+	}
+
+	post, err := h.postUseCase.UnpublishPost(uint(id), middleware.GetUser(c))
+	if err != nil {
+		return h.sendStatusError(c, err)
+	}
+
+	return utils.SendSuccessResponse(c, response.Success, converter.PostToResponse(post))
+}
+
+func (h *PostController) ArchivePost(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.SendErrorResponse(c, response.BadRequest, "ID postingan tidak valid")
+	}
+
+	post, err := h.postUseCase.ArchivePost(uint(id), middleware.GetUser(c))
+	if err != nil {
+		return h.sendStatusError(c, err)
+	}
+
+	return utils.SendSuccessResponse(c, response.Success, converter.PostToResponse(post))
+}
+
+// GetMyDrafts menampilkan post milik user yang login dengan status draft atau scheduled
+func (h *PostController) GetMyDrafts(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	posts, total, err := h.postUseCase.GetMyDrafts(middleware.GetUser(c), page, limit)
+	if err != nil {
+		return utils.SendErrorResponse(c, response.ServerError, err.Error())
+	}
+
+	return utils.SendSuccessResponse(c, response.Success, model.PageResponse[model.PostResponse]{
+		Data: converter.PostsToResponses(posts),
+		PageMetadata: model.NewPageMetadata(page, limit, total),
+	})
+}
+
+func (h *PostController) sendStatusError(c *fiber.Ctx, err error) error {
+	switch {
+	case utils.IsErrNotFound(err):
+		return utils.SendErrorResponse(c, response.ResourceNotFound, err.Error())
+	case utils.IsErrForbidden(err):
+		return utils.SendErrorResponse(c, response.Forbidden, err.Error())
+	case utils.IsErrValidation(err):
+		return utils.SendErrorResponse(c, response.BadRequest, err.Error())
+	}
+	return utils.SendErrorResponse(c, response.ServerError, err.Error())
+}
+
+// GetPostRevisions menampilkan riwayat revisi post, terbaru lebih dulu
+func (h *PostController) GetPostRevisions(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.SendErrorResponse(c, response.BadRequest, "ID postingan tidak valid")
+	}
+
+	revisions, err := h.postUseCase.GetPostRevisions(uint(id), middleware.GetUser(c))
+	if err != nil {
+		return h.sendStatusError(c, err)
+	}
+
+	return utils.SendSuccessResponse(c, response.Success, converter.PostRevisionsToResponses(revisions))
+}
+
+// DiffPostRevisions menampilkan unified diff antara dua revisi, ?from=1&to=3
+func (h *PostController) DiffPostRevisions(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.SendErrorResponse(c, response.BadRequest, "ID postingan tidak valid")
+	}
+
+	from, fromErr := strconv.Atoi(c.Query("from"))
+	to, toErr := strconv.Atoi(c.Query("to"))
+	if fromErr != nil || toErr != nil || from < 1 || to < 1 {
+		return utils.SendErrorResponse(c, response.BadRequest, "Parameter from dan to harus nomor revisi")
+	}
+
+	diff, err := h.postUseCase.DiffPostRevisions(uint(id), from, to, middleware.GetUser(c))
+	if err != nil {
+		return h.sendStatusError(c, err)
+	}
+
+	return utils.SendSuccessResponse(c, response.Success, diff)
+}
+
+// RestorePostRevision mengembalikan isi post ke revisi tertentu sebagai revisi baru
+func (h *PostController) RestorePostRevision(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.SendErrorResponse(c, response.BadRequest, "ID postingan tidak valid")
+	}
+
+	revision, err := strconv.Atoi(c.Params("revision"))
+	if err != nil || revision < 1 {
+		return utils.SendErrorResponse(c, response.BadRequest, "Nomor revisi tidak valid")
+	}
+
+	post, err := h.postUseCase.RestorePostRevision(uint(id), revision, middleware.GetUser(c))
+	if err != nil {
+		return h.sendStatusError(c, err)
+	}
+
+	return utils.SendSuccessResponse(c, response.Success, converter.PostToResponse(post))
+}
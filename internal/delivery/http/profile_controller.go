@@ -0,0 +1,79 @@
+package http
+
+import (
+	"strconv"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/common/response"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/usecase"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+)
+
+type ProfileController struct {
+	Log            *zerolog.Logger
+	profileUseCase usecase.ProfileUseCase
+	validator      *validator.Validate
+}
+
+func NewProfileController(profileUseCase usecase.ProfileUseCase, log *zerolog.Logger, validator *validator.Validate) *ProfileController {
+	return &ProfileController{
+		Log:            log,
+		profileUseCase: profileUseCase,
+		validator:      validator,
+	}
+}
+
+func (c *ProfileController) GetMyProfile(ctx *fiber.Ctx) error {
+	data, err := c.profileUseCase.GetProfile(ctx.Context(), ctx.Locals("userID").(uint))
+	if err != nil {
+		c.Log.Warn().Msgf("Failed to get profile: %v", err)
+		return err
+	}
+
+	return utils.SendSuccessResponse(ctx, response.Success, data)
+}
+
+func (c *ProfileController) UpdateMyProfile(ctx *fiber.Ctx) error {
+	request := new(model.UpdateProfileRequest)
+
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Error().Msgf("Failed to parse request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	if err := c.validator.Struct(request); err != nil {
+		return utils.SendValidatorErrorResponse(ctx, err)
+	}
+
+	data, err := c.profileUseCase.UpdateProfile(ctx.Context(), ctx.Locals("userID").(uint), request)
+	if err != nil {
+		c.Log.Warn().Msgf("Failed to update profile: %v", err)
+		return err
+	}
+
+	return utils.SendSuccessResponse(ctx, response.Success, data)
+}
+
+// GetAuthor adalah halaman publik penulis: profil dan post yang sudah terbit, dengan paginasi page dan limit
+func (c *ProfileController) GetAuthor(ctx *fiber.Ctx) error {
+	page, _ := strconv.Atoi(ctx.Query("page", "1"))
+	limit, _ := strconv.Atoi(ctx.Query("limit", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	data, err := c.profileUseCase.GetAuthor(ctx.Context(), ctx.Params("username"), page, limit)
+	if err != nil {
+		c.Log.Warn().Msgf("Failed to get author: %v", err)
+		return err
+	}
+
+	return utils.SendSuccessResponse(ctx, response.Success, data)
+}
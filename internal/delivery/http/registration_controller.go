@@ -0,0 +1,101 @@
+package http
+
+import (
+	"strconv"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/common/response"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/delivery/http/middleware"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/usecase"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+)
+
+// RegistrationController menangani pengaturan mode registrasi dan kode undangan oleh admin
+type RegistrationController struct {
+	Log         *zerolog.Logger
+	userUseCase usecase.UserUseCase
+	validator   *validator.Validate
+}
+
+func NewRegistrationController(userUseCase usecase.UserUseCase, log *zerolog.Logger, validator *validator.Validate) *RegistrationController {
+	return &RegistrationController{
+		Log:         log,
+		userUseCase: userUseCase,
+		validator:   validator,
+	}
+}
+
+func (c *RegistrationController) GetMode(ctx *fiber.Ctx) error {
+	data, err := c.userUseCase.GetRegistrationMode()
+	if err != nil {
+		return err
+	}
+
+	return utils.SendSuccessResponse(ctx, response.Success, data)
+}
+
+func (c *RegistrationController) SetMode(ctx *fiber.Ctx) error {
+	request := new(model.RegistrationModeRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Error().Msgf("Failed to parse request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	if err := c.validator.Struct(request); err != nil {
+		return utils.SendValidatorErrorResponse(ctx, err)
+	}
+
+	data, err := c.userUseCase.SetRegistrationMode(middleware.GetUser(ctx), request)
+	if err != nil {
+		c.Log.Warn().Msgf("Failed to set registration mode: %v", err)
+		return err
+	}
+
+	return utils.SendSuccessResponse(ctx, response.Success, data)
+}
+
+func (c *RegistrationController) CreateInvitation(ctx *fiber.Ctx) error {
+	request := new(model.CreateInvitationRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Error().Msgf("Failed to parse request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	if err := c.validator.Struct(request); err != nil {
+		return utils.SendValidatorErrorResponse(ctx, err)
+	}
+
+	data, err := c.userUseCase.CreateInvitation(ctx.Context(), middleware.GetUser(ctx), request)
+	if err != nil {
+		c.Log.Warn().Msgf("Failed to create invitation: %v", err)
+		return err
+	}
+
+	return utils.SendSuccessResponse(ctx, response.Created, data)
+}
+
+func (c *RegistrationController) ListInvitations(ctx *fiber.Ctx) error {
+	data, err := c.userUseCase.ListInvitations()
+	if err != nil {
+		return err
+	}
+
+	return utils.SendSuccessResponse(ctx, response.Success, data)
+}
+
+func (c *RegistrationController) RevokeInvitation(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 32)
+	if err != nil {
+		return utils.SendErrorResponse(ctx, response.BadRequest, "ID undangan tidak valid")
+	}
+
+	if err := c.userUseCase.RevokeInvitation(middleware.GetUser(ctx), uint(id)); err != nil {
+		c.Log.Warn().Msgf("Failed to revoke invitation: %v", err)
+		return err
+	}
+
+	return utils.SendSuccessResponse(ctx, response.Success)
+}
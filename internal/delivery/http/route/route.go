@@ -3,17 +3,36 @@ package route
 import (
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/delivery/http"
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/delivery/http/middleware"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/repository"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/usecase"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
 	"github.com/gofiber/fiber/v2"
 	"github.com/knadh/koanf"
+	"github.com/rs/zerolog"
 )
 
 type RouteConfig struct {
-	App                *fiber.App
-	Config             *koanf.Koanf
-	UserController     *http.UserController
-	PostController     *http.PostController
-	CategoryController *http.CategoryController
-	CommentController  *http.CommentController
+	App                       *fiber.App
+	Config                    *koanf.Koanf
+	Log                       *zerolog.Logger
+	UserController            *http.UserController
+	PostController            *http.PostController
+	CategoryController        *http.CategoryController
+	CommentController         *http.CommentController
+	APIKeyController          *http.APIKeyController
+	TwoFactorController       *http.TwoFactorController
+	OIDCController            *http.OIDCController
+	SessionController         *http.SessionController
+	ProfileController         *http.ProfileController
+	AuditController           *http.AuditController
+	RegistrationController    *http.RegistrationController
+	AccountDeletionController *http.AccountDeletionController
+	TokenRepository           repository.TokenRepository
+	APIKeyUseCase             usecase.APIKeyUseCase
+	SessionUseCase            usecase.SessionUseCase
+	JWKSController            *http.JWKSController
+	JWTKeys                   *utils.KeySet
 }
 
 func (c *RouteConfig) Setup() {
@@ -22,14 +41,26 @@ func (c *RouteConfig) Setup() {
 }
 
 func (c *RouteConfig) SetupGuestRoute() {
+	c.App.Get("/.well-known/jwks.json", c.JWKSController.GetJWKS)
+
 	api := c.App.Group("/api/v1")
 
 	auth := api.Group("/auth")
 	auth.Post("/register", c.UserController.Register)
 	auth.Post("/login", c.UserController.Login)
+	auth.Post("/refresh", c.UserController.Refresh)
+	auth.Get("/verify", c.UserController.Verify)
+	auth.Post("/verify/resend", c.UserController.ResendVerification)
+	auth.Post("/password/forgot", c.UserController.ForgotPassword)
+	auth.Post("/password/reset", c.UserController.ResetPassword)
+	auth.Post("/2fa/verify", c.TwoFactorController.Verify)
+	auth.Post("/2fa/setup", c.TwoFactorController.Setup)
+	auth.Get("/oidc/:provider/login", c.OIDCController.Login)
+	auth.Get("/oidc/:provider/callback", c.OIDCController.Callback)
 
 	posts := api.Group("/posts")
 	posts.Get("/", c.PostController.GetAllPosts)
+	posts.Get("/search", c.PostController.SearchPosts)
 	posts.Get("/:id", c.PostController.GetPostByID)
 	posts.Get("/slug/:slug", c.PostController.GetPostBySlug)
 
@@ -39,34 +70,90 @@ func (c *RouteConfig) SetupGuestRoute() {
 	categories := api.Group("/categories")
 	categories.Get("/", c.CategoryController.GetAllCategories)
 	categories.Get("/:id", c.CategoryController.GetCategoryByID)
+
+	api.Get("/authors/:username", c.ProfileController.GetAuthor)
 }
 
 func (c *RouteConfig) SetupAuthRoute() {
 	api := c.App.Group("/api/v1")
-	api.Use(middleware.JWTMiddleware(c.Config))
+	api.Use(middleware.JWTMiddleware(c.JWTKeys, c.TokenRepository, c.APIKeyUseCase, c.SessionUseCase))
+	api.Use(middleware.ImpersonationGuard(c.Log, c.Config.Strings("impersonation.allowed_destructive")))
 
+	adminOnly := middleware.RequireRole(entity.UserRoleAdmin)
+	selfOrAdmin := middleware.RequireSelfOrRole("id", entity.UserRoleAdmin)
+	authorOrAdmin := middleware.RequireRole(entity.UserRoleAuthor, entity.UserRoleAdmin)
+	interactiveOnly := middleware.DenyAPIKey()
+	noImpersonation := middleware.DenyImpersonation()
 
 	auth := api.Group("/auth")
-	auth.Get("/me", c.UserController.GetCurrentUser)
+	auth.Get("/me", middleware.RequireScope(entity.ScopeUsersRead), c.UserController.GetCurrentUser)
+	auth.Get("/me/profile", middleware.RequireScope(entity.ScopeUsersRead), c.ProfileController.GetMyProfile)
+	auth.Put("/me/profile", middleware.RequireScope(entity.ScopeUsersWrite), c.ProfileController.UpdateMyProfile)
+	auth.Get("/me/drafts", middleware.RequireScope(entity.ScopePostsRead), authorOrAdmin, c.PostController.GetMyDrafts)
+	auth.Post("/logout", interactiveOnly, c.UserController.Logout)
+	auth.Post("/logout-all", interactiveOnly, noImpersonation, c.UserController.LogoutAll)
+	auth.Get("/sessions", interactiveOnly, noImpersonation, c.SessionController.List)
+	auth.Delete("/sessions/:id", interactiveOnly, noImpersonation, c.SessionController.Revoke)
+	auth.Get("/me/deletion", interactiveOnly, c.AccountDeletionController.Get)
+	auth.Post("/me/deletion", interactiveOnly, noImpersonation, c.AccountDeletionController.Schedule)
+	auth.Delete("/me/deletion", interactiveOnly, noImpersonation, c.AccountDeletionController.Cancel)
+
+	twoFactor := auth.Group("/2fa", interactiveOnly, noImpersonation)
+	twoFactor.Post("/enroll", c.TwoFactorController.Enroll)
+	twoFactor.Post("/enable", c.TwoFactorController.Enable)
+	twoFactor.Post("/disable", c.TwoFactorController.Disable)
+
+	apiKeys := auth.Group("/api-keys", interactiveOnly, noImpersonation)
+	apiKeys.Post("/", c.APIKeyController.Create)
+	apiKeys.Get("/", c.APIKeyController.List)
+	apiKeys.Delete("/:id", c.APIKeyController.Revoke)
+
+	admin := api.Group("/admin", interactiveOnly, noImpersonation, adminOnly)
+	admin.Get("/2fa/required-roles", c.TwoFactorController.GetRequiredRoles)
+	admin.Put("/2fa/required-roles", c.TwoFactorController.SetRequiredRoles)
+	admin.Get("/password-hashes", c.UserController.PasswordHashReport)
+	admin.Get("/audit", c.AuditController.List)
+	admin.Get("/posts", c.PostController.GetAllPosts)
+	admin.Get("/registration-mode", c.RegistrationController.GetMode)
+	admin.Put("/registration-mode", c.RegistrationController.SetMode)
+	admin.Post("/invitations", c.RegistrationController.CreateInvitation)
+	admin.Get("/invitations", c.RegistrationController.ListInvitations)
+	admin.Delete("/invitations/:id", c.RegistrationController.RevokeInvitation)
+	admin.Post("/users/:id/impersonate", c.UserController.Impersonate)
+
+	usersRead := middleware.RequireScope(entity.ScopeUsersRead)
+	usersWrite := middleware.RequireScope(entity.ScopeUsersWrite)
 
 	users := api.Group("/users")
-	users.Get("/", c.UserController.GetAllUsers)   
-	users.Get("/:id", c.UserController.GetUserByID) 
-	users.Put("/:id", c.UserController.UpdateUser)
-	users.Delete("/:id", c.UserController.DeleteUser)
+	users.Get("/", usersRead, adminOnly, c.UserController.GetAllUsers)
+	users.Get("/:id", usersRead, selfOrAdmin, c.UserController.GetUserByID)
+	users.Put("/:id", usersWrite, noImpersonation, selfOrAdmin, c.UserController.UpdateUser)
+	users.Delete("/:id", usersWrite, selfOrAdmin, c.UserController.DeleteUser)
+	users.Delete("/:id/lockout", usersWrite, adminOnly, c.UserController.UnlockLogin)
+
+	postsWrite := middleware.RequireScope(entity.ScopePostsWrite)
 
 	post := api.Group("/posts")
-	post.Post("/", c.PostController.CreatePost)
-	post.Put("/:id", c.PostController.UpdatePost)
-	post.Delete("/:id", c.PostController.DeletePost)
+	post.Post("/", postsWrite, authorOrAdmin, c.PostController.CreatePost)
+	post.Put("/:id", postsWrite, authorOrAdmin, c.PostController.UpdatePost)
+	post.Delete("/:id", postsWrite, authorOrAdmin, c.PostController.DeletePost)
+	post.Post("/:id/publish", postsWrite, authorOrAdmin, c.PostController.PublishPost)
+	post.Post("/:id/unpublish", postsWrite, authorOrAdmin, c.PostController.UnpublishPost)
+	post.Post("/:id/archive", postsWrite, authorOrAdmin, c.PostController.ArchivePost)
+	post.Get("/:id/revisions", middleware.RequireScope(entity.ScopePostsRead), authorOrAdmin, c.PostController.GetPostRevisions)
+	post.Get("/:id/revisions/diff", middleware.RequireScope(entity.ScopePostsRead), authorOrAdmin, c.PostController.DiffPostRevisions)
+	post.Post("/:id/revisions/:revision/restore", postsWrite, authorOrAdmin, c.PostController.RestorePostRevision)
+
+	commentsWrite := middleware.RequireScope(entity.ScopeCommentsWrite)
 
 	comments := api.Group("/comments")
-	post.Post("/:postID/comments", c.CommentController.CreateComment)
-	comments.Put("/:commentID", c.CommentController.UpdateComment)
-	comments.Delete("/:commentID", c.CommentController.DeleteComment)
+	post.Post("/:postID/comments", commentsWrite, c.CommentController.CreateComment)
+	comments.Put("/:commentID", commentsWrite, c.CommentController.UpdateComment)
+	comments.Delete("/:commentID", commentsWrite, c.CommentController.DeleteComment)
 
-	categories := api.Group("/categories")
-	categories.Post("/", c.CategoryController.CreateCategory)
-	categories.Put("/:id", c.CategoryController.UpdateCategory)
+	categoriesWrite := middleware.RequireScope(entity.ScopeCategoriesWrite)
 
+	categories := api.Group("/categories")
+	categories.Post("/", categoriesWrite, adminOnly, c.CategoryController.CreateCategory)
+	categories.Put("/:id", categoriesWrite, adminOnly, c.CategoryController.UpdateCategory)
 }
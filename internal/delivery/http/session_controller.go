@@ -0,0 +1,43 @@
+package http
+
+import (
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/common/response"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/usecase"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+)
+
+type SessionController struct {
+	Log            *zerolog.Logger
+	sessionUseCase usecase.SessionUseCase
+}
+
+func NewSessionController(sessionUseCase usecase.SessionUseCase, log *zerolog.Logger) *SessionController {
+	return &SessionController{
+		Log:            log,
+		sessionUseCase: sessionUseCase,
+	}
+}
+
+// List menampilkan sesi login aktif milik user; sesi dari token yang sedang dipakai ditandai current
+func (c *SessionController) List(ctx *fiber.Ctx) error {
+	claims := ctx.Locals("claims").(*utils.Claims)
+
+	data, err := c.sessionUseCase.List(ctx.Context(), ctx.Locals("userID").(uint), claims.SessionID)
+	if err != nil {
+		c.Log.Warn().Msgf("Failed to list sessions: %v", err)
+		return err
+	}
+
+	return utils.SendSuccessResponse(ctx, response.Success, data)
+}
+
+func (c *SessionController) Revoke(ctx *fiber.Ctx) error {
+	if err := c.sessionUseCase.Revoke(ctx.Context(), ctx.Locals("userID").(uint), ctx.Params("id")); err != nil {
+		c.Log.Warn().Msgf("Failed to revoke session: %v", err)
+		return err
+	}
+
+	return utils.SendSuccessResponse(ctx, response.Success)
+}
@@ -0,0 +1,163 @@
+package http
+
+import (
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/common/response"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/delivery/http/middleware"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/usecase"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+)
+
+type TwoFactorController struct {
+	Log         *zerolog.Logger
+	userUseCase usecase.UserUseCase
+	validator   *validator.Validate
+}
+
+func NewTwoFactorController(userUseCase usecase.UserUseCase, log *zerolog.Logger, validator *validator.Validate) *TwoFactorController {
+	return &TwoFactorController{
+		Log:         log,
+		userUseCase: userUseCase,
+		validator:   validator,
+	}
+}
+
+// Verify menyelesaikan login dengan challenge token dari /auth/login dan kode TOTP atau kode pemulihan
+func (c *TwoFactorController) Verify(ctx *fiber.Ctx) error {
+	request := new(model.TwoFactorVerifyRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Error().Msgf("Failed to parse request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	if err := c.validator.Struct(request); err != nil {
+		return utils.SendValidatorErrorResponse(ctx, err)
+	}
+
+	request.ClientInfo = clientInfo(ctx)
+
+	data, err := c.userUseCase.VerifyTwoFactor(ctx.Context(), request)
+	if err != nil {
+		c.Log.Warn().Msgf("Failed to verify 2FA: %v", err)
+		return err
+	}
+
+	return utils.SendSuccessResponse(ctx, response.Success, data)
+}
+
+// Setup dipakai user yang role-nya wajib 2FA untuk mendaftarkan authenticator sebelum login selesai
+func (c *TwoFactorController) Setup(ctx *fiber.Ctx) error {
+	request := new(model.TwoFactorSetupRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Error().Msgf("Failed to parse request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	if err := c.validator.Struct(request); err != nil {
+		return utils.SendValidatorErrorResponse(ctx, err)
+	}
+
+	data, err := c.userUseCase.SetupTwoFactor(ctx.Context(), request)
+	if err != nil {
+		c.Log.Warn().Msgf("Failed to setup 2FA: %v", err)
+		return err
+	}
+
+	return utils.SendSuccessResponse(ctx, response.Success, data)
+}
+
+func (c *TwoFactorController) Enroll(ctx *fiber.Ctx) error {
+	data, err := c.userUseCase.EnrollTwoFactor(ctx.Context(), middleware.GetUser(ctx).ID)
+	if err != nil {
+		c.Log.Warn().Msgf("Failed to enroll 2FA: %v", err)
+		return c.sendError(ctx, err)
+	}
+
+	return utils.SendSuccessResponse(ctx, response.Success, data)
+}
+
+func (c *TwoFactorController) Enable(ctx *fiber.Ctx) error {
+	request := new(model.TwoFactorEnableRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Error().Msgf("Failed to parse request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	if err := c.validator.Struct(request); err != nil {
+		return utils.SendValidatorErrorResponse(ctx, err)
+	}
+	request.ClientInfo = clientInfo(ctx)
+
+	data, err := c.userUseCase.EnableTwoFactor(ctx.Context(), middleware.GetUser(ctx).ID, request)
+	if err != nil {
+		c.Log.Warn().Msgf("Failed to enable 2FA: %v", err)
+		return c.sendError(ctx, err)
+	}
+
+	return utils.SendSuccessResponse(ctx, response.Success, data)
+}
+
+func (c *TwoFactorController) Disable(ctx *fiber.Ctx) error {
+	request := new(model.TwoFactorDisableRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Error().Msgf("Failed to parse request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	if err := c.validator.Struct(request); err != nil {
+		return utils.SendValidatorErrorResponse(ctx, err)
+	}
+	request.ClientInfo = clientInfo(ctx)
+
+	if err := c.userUseCase.DisableTwoFactor(ctx.Context(), middleware.GetUser(ctx).ID, request); err != nil {
+		c.Log.Warn().Msgf("Failed to disable 2FA: %v", err)
+		return c.sendError(ctx, err)
+	}
+
+	return utils.SendSuccessResponse(ctx, response.Success)
+}
+
+func (c *TwoFactorController) GetRequiredRoles(ctx *fiber.Ctx) error {
+	data, err := c.userUseCase.GetTwoFactorRequiredRoles()
+	if err != nil {
+		return err
+	}
+
+	return utils.SendSuccessResponse(ctx, response.Success, data)
+}
+
+func (c *TwoFactorController) SetRequiredRoles(ctx *fiber.Ctx) error {
+	request := new(model.TwoFactorRequiredRolesRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Error().Msgf("Failed to parse request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	if err := c.validator.Struct(request); err != nil {
+		return utils.SendValidatorErrorResponse(ctx, err)
+	}
+
+	data, err := c.userUseCase.SetTwoFactorRequiredRoles(request)
+	if err != nil {
+		c.Log.Warn().Msgf("Failed to set 2FA required roles: %v", err)
+		return err
+	}
+
+	c.Log.Info().Msgf("2FA required roles changed to %v by user %d", data.Roles, middleware.GetUser(ctx).ID)
+	return utils.SendSuccessResponse(ctx, response.Success, data)
+}
+
+func (c *TwoFactorController) sendError(ctx *fiber.Ctx, err error) error {
+	switch {
+	case utils.IsErrValidation(err):
+		return utils.SendErrorResponse(ctx, response.BadRequest, err.Error())
+	case utils.IsErrForbidden(err):
+		return utils.SendErrorResponse(ctx, response.Forbidden, err.Error())
+	case utils.IsErrNotFound(err):
+		return utils.SendErrorResponse(ctx, response.ResourceNotFound, err.Error())
+	}
+	return err
+}
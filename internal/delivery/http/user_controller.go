@@ -2,11 +2,14 @@ package http
 
 import (
 	"errors"
+	"math"
 	"strconv"
 	"strings"
 
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/common/response"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/delivery/http/middleware"
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model/converter"
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/usecase"
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
 	"github.com/go-playground/validator/v10"
@@ -67,15 +70,170 @@ func (c *UserController) Login(ctx *fiber.Ctx) error {
 		return utils.SendValidatorErrorResponse(ctx, err)
 	}
 
+	request.ClientInfo = clientInfo(ctx)
+
 	data, err := c.userUseCase.Login(ctx.Context(), request)
 	if err != nil {
 		c.Log.Warn().Msgf("Failed to login user: %v", err)
+		if e, ok := utils.AsErrTooManyRequests(err); ok {
+			ctx.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(e.RetryAfter.Seconds()))))
+			return utils.SendErrorResponse(ctx, response.TooManyRequests, e.Error())
+		}
 		return err
 	}
 
 	return utils.SendSuccessResponse(ctx, response.Success, data)
 }
 
+func (c *UserController) Verify(ctx *fiber.Ctx) error {
+	request := &model.VerifyUserRequest{
+		Token: ctx.Query("token"),
+	}
+
+	if err := c.validator.Struct(request); err != nil {
+		return utils.SendValidatorErrorResponse(ctx, err)
+	}
+
+	data, err := c.userUseCase.Verify(ctx.Context(), request)
+	if err != nil {
+		c.Log.Warn().Msgf("Failed to verify user: %v", err)
+		return err
+	}
+
+	return utils.SendSuccessResponse(ctx, response.Success, data)
+}
+
+func (c *UserController) ResendVerification(ctx *fiber.Ctx) error {
+	request := new(model.ResendVerificationRequest)
+
+	err := ctx.BodyParser(request)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to parse request body: %v", err)
+
+		return fiber.ErrBadRequest
+	}
+
+	if err := c.validator.Struct(request); err != nil {
+		return utils.SendValidatorErrorResponse(ctx, err)
+	}
+
+	if err := c.userUseCase.ResendVerification(ctx.Context(), request); err != nil {
+		c.Log.Warn().Msgf("Failed to resend verification email: %v", err)
+		return err
+	}
+
+	return utils.SendSuccessResponse(ctx, response.Success)
+}
+
+func (c *UserController) ForgotPassword(ctx *fiber.Ctx) error {
+	request := new(model.ForgotPasswordRequest)
+
+	err := ctx.BodyParser(request)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to parse request body: %v", err)
+
+		return fiber.ErrBadRequest
+	}
+
+	if err := c.validator.Struct(request); err != nil {
+		return utils.SendValidatorErrorResponse(ctx, err)
+	}
+
+	if err := c.userUseCase.ForgotPassword(ctx.Context(), request); err != nil {
+		c.Log.Warn().Msgf("Failed to process forgot password: %v", err)
+		return err
+	}
+
+	return utils.SendSuccessResponse(ctx, response.Success)
+}
+
+func (c *UserController) ResetPassword(ctx *fiber.Ctx) error {
+	request := new(model.ResetPasswordRequest)
+
+	err := ctx.BodyParser(request)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to parse request body: %v", err)
+
+		return fiber.ErrBadRequest
+	}
+
+	if err := c.validator.Struct(request); err != nil {
+		return utils.SendValidatorErrorResponse(ctx, err)
+	}
+
+	if err := c.userUseCase.ResetPassword(ctx.Context(), request); err != nil {
+		c.Log.Warn().Msgf("Failed to reset password: %v", err)
+		return err
+	}
+
+	return utils.SendSuccessResponse(ctx, response.Success)
+}
+
+func (c *UserController) Refresh(ctx *fiber.Ctx) error {
+	request := new(model.RefreshTokenRequest)
+
+	err := ctx.BodyParser(request)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to parse request body: %v", err)
+
+		return fiber.ErrBadRequest
+	}
+
+	if err := c.validator.Struct(request); err != nil {
+		return utils.SendValidatorErrorResponse(ctx, err)
+	}
+
+	request.ClientInfo = clientInfo(ctx)
+
+	data, err := c.userUseCase.Refresh(ctx.Context(), request)
+	if err != nil {
+		c.Log.Warn().Msgf("Failed to refresh token: %v", err)
+		return err
+	}
+
+	return utils.SendSuccessResponse(ctx, response.Success, data)
+}
+
+func (c *UserController) Logout(ctx *fiber.Ctx) error {
+	request := new(model.LogoutUserRequest)
+
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(request); err != nil {
+			c.Log.Error().Msgf("Failed to parse request body: %v", err)
+
+			return fiber.ErrBadRequest
+		}
+	}
+
+	if err := c.validator.Struct(request); err != nil {
+		return utils.SendValidatorErrorResponse(ctx, err)
+	}
+
+	claims := ctx.Locals("claims").(*utils.Claims)
+	request.UserID = ctx.Locals("userID").(uint)
+	request.TokenID = claims.ID
+	request.SessionID = claims.SessionID
+	request.ExpiresAt = claims.ExpiresAt.Time
+
+	if err := c.userUseCase.Logout(ctx.Context(), request); err != nil {
+		c.Log.Warn().Msgf("Failed to logout user: %v", err)
+		return err
+	}
+
+	return utils.SendSuccessResponse(ctx, response.Success)
+}
+
+func (c *UserController) LogoutAll(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	if err := c.userUseCase.LogoutAll(ctx.Context(), userID); err != nil {
+		c.Log.Warn().Msgf("Failed to logout user from all devices: %v", err)
+		return err
+	}
+
+	return utils.SendSuccessResponse(ctx, response.Success)
+}
+
 func (h *UserController) GetCurrentUser(c *fiber.Ctx) error {
 	userID := c.Locals("userID").(uint)
 	user, err := h.userUseCase.GetUserByID(userID)
@@ -83,9 +241,13 @@ func (h *UserController) GetCurrentUser(c *fiber.Ctx) error {
 		if errors.Is(err, utils.ErrNotFound("")) {
 			return utils.SendErrorResponse(c, response.BadRequest, err.Error())
 		}
+		var fiberErr *fiber.Error
+		if errors.As(err, &fiberErr) {
+			return err
+		}
 		return utils.SendErrorResponse(c, response.ServerError, err.Error())
 	}
-	return utils.SendSuccessResponse(c, response.Success, user)
+	return utils.SendSuccessResponse(c, response.Success, converter.UserToDetailResponse(user))
 }
 
 func (h *UserController) GetAllUsers(c *fiber.Ctx) error {
@@ -93,7 +255,7 @@ func (h *UserController) GetAllUsers(c *fiber.Ctx) error {
 	if err != nil {
 		return utils.SendErrorResponse(c, response.ServerError, err.Error())
 	}
-	return utils.SendSuccessResponse(c, response.Success, users)
+	return utils.SendSuccessResponse(c, response.Success, converter.UsersToDetailResponses(users))
 }
 
 func (h *UserController) GetUserByID(c *fiber.Ctx) error {
@@ -109,7 +271,7 @@ func (h *UserController) GetUserByID(c *fiber.Ctx) error {
 		}
 		return utils.SendErrorResponse(c, response.ServerError, err.Error())
 	}
-	return utils.SendSuccessResponse(c, response.Success, "Pengguna berhasil diambil", user)
+	return utils.SendSuccessResponse(c, response.Success, converter.UserToDetailResponse(user))
 }
 
 func (h *UserController) UpdateUser(c *fiber.Ctx) error {
@@ -118,17 +280,6 @@ func (h *UserController) UpdateUser(c *fiber.Ctx) error {
 		return utils.SendErrorResponse(c, response.BadRequest, "ID pengguna tidak valid")
 	}
 
-	// Otorisasi: Hanya user yang sedang login atau admin yang bisa mengupdate dirinya/user lain.
-	// Contoh sederhana: hanya user itu sendiri atau jika ada mekanisme admin.
-	// loggedInUserID := c.Locals("userID").(uint)
-	// Jika user mencoba update user lain DAN bukan admin (jika ada peran admin), tolak.
-	// Untuk demo ini, kita izinkan user yang login mengupdate dirinya sendiri.
-	// Jika Anda ingin hanya admin yang bisa update user lain, tambahkan middleware `IsAdmin`
-	// sebelum endpoint ini, atau tambahkan logika role di handler ini.
-	// if uint(id) != loggedInUserID /* && !c.Locals("isAdmin").(bool) <-- contoh jika ada middleware admin */ {
-	// 	return utils.SendErrorResponse(c, http.StatusForbidden, "Anda tidak memiliki izin untuk memperbarui pengguna ini")
-	// }
-
 	var req model.UpdateUserRequest
 	if err := c.BodyParser(&req); err != nil {
 		return utils.SendErrorResponse(c, response.BadRequest, "Invalid request body")
@@ -138,8 +289,11 @@ func (h *UserController) UpdateUser(c *fiber.Ctx) error {
 		return utils.SendValidatorErrorResponse(c, err)
 	}
 
-	user, err := h.userUseCase.UpdateUser(uint(id), req.Username, req.Email, req.Password, req.Role)
+	user, err := h.userUseCase.UpdateUser(uint(id), req.Username, req.Email, req.Password, req.Role, middleware.GetUser(c))
 	if err != nil {
+		if utils.IsErrForbidden(err) {
+			return utils.SendErrorResponse(c, response.Forbidden, err.Error())
+		}
 		if errors.Is(err, utils.ErrNotFound("")) {
 			return utils.SendErrorResponse(c, response.BadRequest, err.Error())
 		}
@@ -151,7 +305,7 @@ func (h *UserController) UpdateUser(c *fiber.Ctx) error {
 		}
 		return utils.SendErrorResponse(c, response.ServerError, err.Error())
 	}
-	return utils.SendSuccessResponse(c, response.Success, user)
+	return utils.SendSuccessResponse(c, response.Success, converter.UserToDetailResponse(user))
 }
 
 func (h *UserController) DeleteUser(c *fiber.Ctx) error {
@@ -160,15 +314,11 @@ func (h *UserController) DeleteUser(c *fiber.Ctx) error {
 		return utils.SendErrorResponse(c, response.BadRequest, "ID pengguna tidak valid")
 	}
 
-	// Otorisasi: Hanya admin yang boleh menghapus user lain.
-	// Atau user hanya boleh menghapus akunnya sendiri.
-	// loggedInUserID := c.Locals("userID").(uint)
-	// if uint(id) != loggedInUserID  {
-	// 	return utils.SendErrorResponse(c, http.StatusForbidden, "Anda tidak memiliki izin untuk menghapus pengguna ini")
-	// }
-
-	err = h.userUseCase.DeleteUser(uint(id))
+	err = h.userUseCase.DeleteUser(uint(id), middleware.GetUser(c))
 	if err != nil {
+		if utils.IsErrForbidden(err) {
+			return utils.SendErrorResponse(c, response.Forbidden, err.Error())
+		}
 		if errors.Is(err, utils.ErrNotFound("")) {
 			return utils.SendErrorResponse(c, response.BadRequest, err.Error())
 		}
@@ -176,3 +326,68 @@ func (h *UserController) DeleteUser(c *fiber.Ctx) error {
 	}
 	return utils.SendSuccessResponse(c, response.Success)
 }
+
+func (h *UserController) UnlockLogin(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.SendErrorResponse(c, response.BadRequest, "ID pengguna tidak valid")
+	}
+
+	err = h.userUseCase.UnlockLogin(c.Context(), uint(id), middleware.GetUser(c))
+	if err != nil {
+		if utils.IsErrForbidden(err) {
+			return utils.SendErrorResponse(c, response.Forbidden, err.Error())
+		}
+		if utils.IsErrNotFound(err) {
+			return utils.SendErrorResponse(c, response.ResourceNotFound, err.Error())
+		}
+		return err
+	}
+	return utils.SendSuccessResponse(c, response.Success)
+}
+
+// PasswordHashReport menampilkan jumlah akun per parameter argon2id; akun lama di-rehash saat login berikutnya
+func (c *UserController) PasswordHashReport(ctx *fiber.Ctx) error {
+	data, err := c.userUseCase.PasswordHashReport()
+	if err != nil {
+		return err
+	}
+
+	return utils.SendSuccessResponse(ctx, response.Success, data)
+}
+
+// Impersonate menerbitkan token impersonation untuk user :id; hanya admin dan tanpa refresh token
+func (c *UserController) Impersonate(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 32)
+	if err != nil {
+		return utils.SendErrorResponse(ctx, response.BadRequest, "ID pengguna tidak valid")
+	}
+
+	data, err := c.userUseCase.Impersonate(ctx.Context(), middleware.GetUser(ctx), uint(id))
+	if err != nil {
+		c.Log.Warn().Msgf("Failed to impersonate user: %v", err)
+		switch {
+		case utils.IsErrValidation(err):
+			return utils.SendErrorResponse(ctx, response.BadRequest, err.Error())
+		case utils.IsErrForbidden(err):
+			return utils.SendErrorResponse(ctx, response.Forbidden, err.Error())
+		case utils.IsErrNotFound(err):
+			return utils.SendErrorResponse(ctx, response.ResourceNotFound, err.Error())
+		}
+		return err
+	}
+
+	return utils.SendSuccessResponse(ctx, response.Success, data)
+}
+
+// clientInfo mengambil IP dan User-Agent untuk dicatat pada sesi login
+func clientInfo(ctx *fiber.Ctx) model.ClientInfo {
+	userAgent := ctx.Get(fiber.HeaderUserAgent)
+	if len(userAgent) > 255 {
+		userAgent = userAgent[:255]
+	}
+	return model.ClientInfo{
+		IP:        ctx.IP(),
+		UserAgent: userAgent,
+	}
+}
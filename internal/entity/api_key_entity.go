@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	ScopePostsRead       = "posts:read"
+	ScopePostsWrite      = "posts:write"
+	ScopeCommentsRead    = "comments:read"
+	ScopeCommentsWrite   = "comments:write"
+	ScopeCategoriesWrite = "categories:write"
+	ScopeUsersRead       = "users:read"
+	ScopeUsersWrite      = "users:write"
+)
+
+var APIKeyScopes = []string{
+	ScopePostsRead, ScopePostsWrite,
+	ScopeCommentsRead, ScopeCommentsWrite,
+	ScopeCategoriesWrite,
+	ScopeUsersRead, ScopeUsersWrite,
+}
+
+func IsValidAPIKeyScope(scope string) bool {
+	for _, s := range APIKeyScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKey hanya menyimpan hash dari key; Prefix dipakai untuk mencari key tanpa perlu memindai seluruh tabel.
+// Scopes disimpan sebagai daftar yang dipisahkan spasi.
+type APIKey struct {
+	ID         uint   `gorm:"primaryKey"`
+	UserID     uint   `gorm:"not null"`
+	Name       string `gorm:"not null"`
+	Prefix     string `gorm:"unique;not null"`
+	KeyHash    string `gorm:"not null"`
+	Scopes     string `gorm:"not null"`
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  *time.Time
+}
+
+func (*APIKey) TableName() string {
+	return "api_keys"
+}
+
+func (k *APIKey) ScopeList() []string {
+	return strings.Fields(k.Scopes)
+}
+
+func (k *APIKey) IsActive(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || k.ExpiresAt.After(now))
+}
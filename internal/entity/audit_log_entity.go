@@ -0,0 +1,47 @@
+package entity
+
+import "time"
+
+const (
+	AuditActionUserUpdate           = "user.update"
+	AuditActionUserDelete           = "user.delete"
+	AuditActionUserRoleChange       = "user.role_change"
+	AuditActionPostDelete           = "post.delete"
+	AuditActionPostStatus           = "post.status_change"
+	AuditActionPostRevisionRestore  = "post.revision_restore"
+	AuditActionCommentDelete        = "comment.delete"
+	AuditActionUserImpersonate      = "user.impersonate"
+	AuditActionUserDeletionSchedule = "user.deletion_schedule"
+	AuditActionUserDeletionCancel   = "user.deletion_cancel"
+
+	AuditActionRegistrationMode = "registration.mode_change"
+	AuditActionInvitationCreate = "invitation.create"
+	AuditActionInvitationRevoke = "invitation.revoke"
+)
+
+const (
+	AuditTargetUser    = "user"
+	AuditTargetPost    = "post"
+	AuditTargetComment = "comment"
+
+	AuditTargetSetting    = "setting"
+	AuditTargetInvitation = "invitation"
+)
+
+// AuditLog adalah catatan append-only; Changes berisi JSON {"field": {"before": ..., "after": ...}}
+type AuditLog struct {
+	ID             uint64 `gorm:"primaryKey"`
+	ActorID        *uint
+	ImpersonatorID *uint
+	Action         string `gorm:"not null"`
+	TargetType     string `gorm:"not null"`
+	TargetID       uint   `gorm:"not null"`
+	Changes        string `gorm:"type:jsonb;not null"`
+	IP             string
+	RequestID      string
+	CreatedAt      time.Time
+}
+
+func (*AuditLog) TableName() string {
+	return "audit_logs"
+}
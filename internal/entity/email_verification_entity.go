@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+type EmailVerificationToken struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    uint      `gorm:"not null"`
+	TokenHash string    `gorm:"unique;not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	CreatedAt *time.Time
+}
+
+func (*EmailVerificationToken) TableName() string {
+	return "email_verification_tokens"
+}
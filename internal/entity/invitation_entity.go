@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+const SettingRegistrationMode = "registration.mode"
+
+const (
+	RegistrationModeOpen       = "open"
+	RegistrationModeInviteOnly = "invite_only"
+	RegistrationModeClosed     = "closed"
+)
+
+func IsValidRegistrationMode(mode string) bool {
+	return mode == RegistrationModeOpen || mode == RegistrationModeInviteOnly || mode == RegistrationModeClosed
+}
+
+// Invitation hanya menyimpan hash kode; kode aslinya ditampilkan sekali saat dibuat
+type Invitation struct {
+	ID        uint     `gorm:"primaryKey"`
+	CodeHash  string   `gorm:"not null"`
+	Role      UserRole `gorm:"not null"`
+	MaxUses   int      `gorm:"not null"`
+	UsedCount int      `gorm:"not null"`
+	ExpiresAt time.Time
+	CreatedBy *uint
+	RevokedAt *time.Time
+	CreatedAt *time.Time
+}
+
+func (*Invitation) TableName() string {
+	return "invitations"
+}
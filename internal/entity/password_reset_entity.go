@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+type PasswordResetToken struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    uint      `gorm:"not null"`
+	TokenHash string    `gorm:"unique;not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt *time.Time
+}
+
+func (*PasswordResetToken) TableName() string {
+	return "password_reset_tokens"
+}
@@ -9,6 +9,7 @@ type Post struct {
 	Content     string     `gorm:"type:text;colomn:content" json:"content"`
 	AuthorID    uint       `gorm:"colomn:author_id;not null" json:"authorId"`
 	Author      User       `gorm:"foreignKey:AuthorID" json:"author"`
+	Status      PostStatus `gorm:"column:status;default:draft" json:"status"`
 	PublishedAt *time.Time `gorm:"colomn:published_at" json:"publishedAt"`
 	Categories  []Category `json:"categories" gorm:"many2many:post_categories;"`
 	Comments    []Comment  `json:"comments"` 
@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// PostRevision adalah salinan isi post setelah setiap perubahan; Revision berurutan mulai dari 1 per post
+type PostRevision struct {
+	ID         uint64   `gorm:"primaryKey"`
+	PostID     uint     `gorm:"not null"`
+	Revision   int      `gorm:"not null"`
+	Title      string   `gorm:"not null"`
+	Content    string   `gorm:"type:text;not null"`
+	Categories []string `gorm:"serializer:json;type:jsonb;not null"`
+	EditorID   *uint
+	Editor     *User `gorm:"foreignKey:EditorID"`
+	CreatedAt  time.Time
+}
+
+func (*PostRevision) TableName() string {
+	return "post_revisions"
+}
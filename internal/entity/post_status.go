@@ -0,0 +1,29 @@
+package entity
+
+type PostStatus string
+
+// Nilai status harus sama persis dengan enum post_status di db/migrations/000016_add_post_status.up.sql
+const (
+	PostStatusDraft     PostStatus = "draft"
+	PostStatusScheduled PostStatus = "scheduled"
+	PostStatusPublished PostStatus = "published"
+	PostStatusArchived  PostStatus = "archived"
+)
+
+// postStatusTransitions adalah perpindahan status yang diizinkan. Post terjadwal boleh dijadwalkan ulang,
+// sedangkan post yang diarsipkan harus dikembalikan ke draft sebelum diterbitkan ulang.
+var postStatusTransitions = map[PostStatus][]PostStatus{
+	PostStatusDraft:     {PostStatusScheduled, PostStatusPublished, PostStatusArchived},
+	PostStatusScheduled: {PostStatusDraft, PostStatusScheduled, PostStatusPublished, PostStatusArchived},
+	PostStatusPublished: {PostStatusDraft, PostStatusArchived},
+	PostStatusArchived:  {PostStatusDraft},
+}
+
+func (s PostStatus) CanTransitionTo(next PostStatus) bool {
+	for _, allowed := range postStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
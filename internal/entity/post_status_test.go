@@ -0,0 +1,42 @@
+package entity
+
+import "testing"
+
+func TestPostStatusCanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from PostStatus
+		to   PostStatus
+		want bool
+	}{
+		{PostStatusDraft, PostStatusDraft, false},
+		{PostStatusDraft, PostStatusScheduled, true},
+		{PostStatusDraft, PostStatusPublished, true},
+		{PostStatusDraft, PostStatusArchived, true},
+
+		{PostStatusScheduled, PostStatusDraft, true},
+		{PostStatusScheduled, PostStatusScheduled, true},
+		{PostStatusScheduled, PostStatusPublished, true},
+		{PostStatusScheduled, PostStatusArchived, true},
+
+		{PostStatusPublished, PostStatusDraft, true},
+		{PostStatusPublished, PostStatusScheduled, false},
+		{PostStatusPublished, PostStatusPublished, false},
+		{PostStatusPublished, PostStatusArchived, true},
+
+		{PostStatusArchived, PostStatusDraft, true},
+		{PostStatusArchived, PostStatusScheduled, false},
+		{PostStatusArchived, PostStatusPublished, false},
+		{PostStatusArchived, PostStatusArchived, false},
+
+		{PostStatus("unknown"), PostStatusDraft, false},
+		{PostStatusDraft, PostStatus("unknown"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+				t.Errorf("CanTransitionTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
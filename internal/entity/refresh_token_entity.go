@@ -0,0 +1,8 @@
+package entity
+
+// RefreshToken disimpan di Redis, bukan di database.
+// FamilyID mengikat seluruh token hasil rotasi dari satu login yang sama.
+type RefreshToken struct {
+	UserID   uint   `json:"user_id"`
+	FamilyID string `json:"family_id"`
+}
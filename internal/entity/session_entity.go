@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// Session adalah satu perangkat yang sedang login. ID-nya sama dengan ID refresh token family,
+// sehingga sesi tetap sama walaupun refresh token dirotasi, dan disimpan di Redis selama refresh token berlaku.
+type Session struct {
+	ID           string
+	UserID       uint
+	UserAgent    string
+	IP           string
+	CreatedAt    time.Time
+	LastActiveAt time.Time
+}
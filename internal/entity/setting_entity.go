@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+const SettingTwoFactorRequiredRoles = "two_factor.required_roles"
+
+type Setting struct {
+	Key       string `gorm:"primaryKey"`
+	Value     string `gorm:"not null"`
+	UpdatedAt *time.Time
+}
+
+func (*Setting) TableName() string {
+	return "app_settings"
+}
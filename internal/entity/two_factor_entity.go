@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+// RecoveryCode hanya menyimpan hash dari kode pemulihan; setiap kode hanya dapat dipakai sekali
+type RecoveryCode struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null"`
+	CodeHash  string `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt *time.Time
+}
+
+func (*RecoveryCode) TableName() string {
+	return "two_factor_recovery_codes"
+}
+
+const (
+	// TwoFactorChallengeLogin diselesaikan dengan kode TOTP atau kode pemulihan
+	TwoFactorChallengeLogin = "login"
+	// TwoFactorChallengeSetup diberikan kepada user yang role-nya wajib 2FA tetapi belum mendaftarkan authenticator
+	TwoFactorChallengeSetup = "setup"
+)
+
+// TwoFactorChallenge disimpan di Redis di antara langkah password dan langkah kode 2FA
+type TwoFactorChallenge struct {
+	UserID  uint   `json:"user_id"`
+	Purpose string `json:"purpose"`
+}
@@ -1,22 +1,61 @@
 package entity
 
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
 type UserRole string
 
+// Nilai role harus sama persis dengan enum user_role di db/migrations/000001_create_table_user.up.sql
 const (
 	UserRoleAdmin  UserRole = "admin"
-	UserRoleAuthor UserRole = "Author"
+	UserRoleAuthor UserRole = "author"
 	UserRoleReader UserRole = "reader"
 )
 
+// GhostUsername adalah user sistem yang dibuat di db/migrations/000015_add_account_deletion.up.sql untuk menampung
+// post dan komentar dari akun yang dihapus. Selalu cari bersama is_system agar tidak tertukar dengan user biasa.
+const GhostUsername = "ghost"
+
+var UserRoles = []UserRole{UserRoleAdmin, UserRoleAuthor, UserRoleReader}
+
+func (r UserRole) IsValid() bool {
+	for _, role := range UserRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
 type User struct {
 	BaseEntity
-	Email        string   `gorm:"colomn:email:unique;not null" json:"email"`
-	PasswordHash string   `gorm:"colomn:password_hash;not null" json:"password_hash"`
-	Username     string   `gorm:"colomn:username;not null" json:"username"`
-	Role         UserRole `gorm:"colomn:role;default:reader" json:"role"`
-	Posts        []Post   `json:"posts" gorm:"foreignKey:AuthorID"`
+	Email           string     `gorm:"colomn:email:unique;not null" json:"email"`
+	PasswordHash    string     `gorm:"colomn:password_hash;not null" json:"-"`
+	Username        string     `gorm:"colomn:username;not null" json:"username"`
+	Role            UserRole   `gorm:"colomn:role;default:reader" json:"role"`
+	EmailVerifiedAt *time.Time `gorm:"colomn:email_verified_at" json:"email_verified_at"`
+	TOTPSecret      *string    `gorm:"column:totp_secret" json:"-"`
+	TOTPEnabledAt   *time.Time `gorm:"column:totp_enabled_at" json:"totp_enabled_at"`
+	DisplayName     string     `gorm:"column:display_name" json:"display_name"`
+	Bio             string     `gorm:"column:bio" json:"bio"`
+	Website         string     `gorm:"column:website" json:"website"`
+	AvatarURL       string     `gorm:"column:avatar_url" json:"avatar_url"`
+	IsSystem        bool       `gorm:"column:is_system;->" json:"-"`
+	// DeletionScheduledAt terisi selama masa tenggang penghapusan akun; DeletionTransferTo nil berarti post dipindahkan ke user ghost
+	DeletionScheduledAt    *time.Time     `gorm:"column:deletion_scheduled_at" json:"-"`
+	DeletionTransferTo     *uint          `gorm:"column:deletion_transfer_to" json:"-"`
+	DeletionDeleteComments bool           `gorm:"column:deletion_delete_comments" json:"-"`
+	DeletedAt              gorm.DeletedAt `gorm:"column:deleted_at" json:"-"`
+	Posts                  []Post         `json:"posts" gorm:"foreignKey:AuthorID"`
 }
 
 func (*User) TableName() string {
 	return "users"
 }
+
+func (u *User) TwoFactorEnabled() bool {
+	return u.TOTPEnabledAt != nil && u.TOTPSecret != nil
+}
@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+// UserIdentity menautkan akun di identity provider eksternal (provider + sub dari id_token) ke user lokal
+type UserIdentity struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null"`
+	Provider  string `gorm:"not null"`
+	Subject   string `gorm:"not null"`
+	Email     string
+	CreatedAt *time.Time
+}
+
+func (*UserIdentity) TableName() string {
+	return "user_identities"
+}
+
+// OIDCState disimpan di Redis selama user berada di halaman login provider, dengan key berupa parameter state
+type OIDCState struct {
+	Provider     string `json:"provider"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+}
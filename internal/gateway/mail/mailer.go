@@ -0,0 +1,63 @@
+package mail
+
+import (
+	"fmt"
+	"net/smtp"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+type Mailer interface {
+	Send(to, subject, body string) error
+}
+
+type Config struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+// NewMailer mengembalikan mailer SMTP, atau mailer yang hanya menulis ke log jika host SMTP tidak diatur
+func NewMailer(config Config, log *zerolog.Logger) Mailer {
+	if config.Host == "" {
+		return &logMailer{Log: log}
+	}
+	return &smtpMailer{config: config}
+}
+
+type smtpMailer struct {
+	config Config
+}
+
+func (m *smtpMailer) Send(to, subject, body string) error {
+	addr := fmt.Sprintf("%s:%d", m.config.Host, m.config.Port)
+
+	var auth smtp.Auth
+	if m.config.Username != "" {
+		auth = smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)
+	}
+
+	message := strings.Join([]string{
+		"From: " + m.config.From,
+		"To: " + to,
+		"Subject: " + subject,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=\"utf-8\"",
+		"",
+		body,
+	}, "\r\n")
+
+	return smtp.SendMail(addr, auth, m.config.From, []string{to}, []byte(message))
+}
+
+type logMailer struct {
+	Log *zerolog.Logger
+}
+
+func (m *logMailer) Send(to, subject, body string) error {
+	m.Log.Info().Str("to", to).Str("subject", subject).Msg(body)
+	return nil
+}
@@ -0,0 +1,42 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+)
+
+// PostPublishedChannel menerima model.PostEvent setiap kali post terbit, baik langsung maupun lewat jadwal
+const PostPublishedChannel = "posts.published"
+
+// Producer mengirim event ke channel Redis pub/sub. Pengiriman bersifat fire-and-forget:
+// subscriber yang tidak sedang terhubung tidak menerima event tersebut.
+type Producer[T model.Event] struct {
+	Redis   *redis.Client
+	Channel string
+	Log     *zerolog.Logger
+}
+
+func NewPostPublishedProducer(redis *redis.Client, log *zerolog.Logger) *Producer[*model.PostEvent] {
+	return &Producer[*model.PostEvent]{
+		Redis:   redis,
+		Channel: PostPublishedChannel,
+		Log:     log,
+	}
+}
+
+func (p *Producer[T]) Send(ctx context.Context, event T) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if err := p.Redis.Publish(ctx, p.Channel, payload).Err(); err != nil {
+		return err
+	}
+	p.Log.Debug().Msgf("Event %d sent to %s", event.GetId(), p.Channel)
+	return nil
+}
@@ -0,0 +1,82 @@
+package pwned
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Checker memeriksa apakah sebuah password pernah muncul di daftar kebocoran data
+type Checker interface {
+	IsBreached(password string) (bool, error)
+}
+
+type Config struct {
+	// Directory berisi satu file per prefix SHA-1 lima karakter (misalnya 5BAA6.txt) dengan baris
+	// "SUFFIX:COUNT", format yang dihasilkan haveibeenpwned-downloader
+	Directory string
+	// MinCount adalah jumlah kemunculan minimal agar password dianggap bocor
+	MinCount int
+}
+
+// NewChecker mengembalikan checker berbasis file prefix (k-anonymity), atau checker yang selalu lolos
+// jika direktori tidak diatur
+func NewChecker(config Config) Checker {
+	if config.Directory == "" {
+		return noopChecker{}
+	}
+	if config.MinCount < 1 {
+		config.MinCount = 1
+	}
+	return &fileChecker{config: config}
+}
+
+type fileChecker struct {
+	config Config
+}
+
+// IsBreached hanya membaca file untuk lima karakter pertama hash, sehingga daftar lengkap tidak perlu dimuat ke memori
+func (c *fileChecker) IsBreached(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:5], hash[5:]
+
+	file, err := os.Open(filepath.Join(c.config.Directory, prefix+".txt"))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		candidate, count, found := strings.Cut(line, ":")
+		if !strings.EqualFold(candidate, suffix) {
+			continue
+		}
+		if !found {
+			return true, nil
+		}
+		n, err := strconv.Atoi(count)
+		if err != nil {
+			return true, nil
+		}
+		return n >= c.config.MinCount, nil
+	}
+	return false, scanner.Err()
+}
+
+type noopChecker struct{}
+
+func (noopChecker) IsBreached(string) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+type AccountDeletionRequest struct {
+	Password string `json:"password" validate:"required,max=100"`
+	// TransferTo adalah username author yang menerima post; kosong berarti post dipindahkan ke user ghost
+	TransferTo string `json:"transfer_to" validate:"omitempty,max=100"`
+	// DeleteComments menghapus komentar alih-alih menganonimkannya
+	DeleteComments bool `json:"delete_comments"`
+}
+
+type AccountDeletionResponse struct {
+	ScheduledAt    time.Time `json:"scheduled_at"`
+	TransferTo     string    `json:"transfer_to"`
+	DeleteComments bool      `json:"delete_comments"`
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditChange struct {
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+}
+
+// AuditChanges dipetakan per nama field; field sensitif seperti password hanya dicatat sebagai "changed"
+type AuditChanges map[string]AuditChange
+
+// Set hanya mencatat field yang nilainya benar-benar berubah
+func (c AuditChanges) Set(field string, before, after interface{}) {
+	if before == after {
+		return
+	}
+	c[field] = AuditChange{Before: before, After: after}
+}
+
+type ListAuditLogRequest struct {
+	ActorID    *uint  `query:"actor_id"`
+	Action     string `query:"action" validate:"omitempty,max=50"`
+	TargetType string `query:"target_type" validate:"omitempty,max=50"`
+	TargetID   *uint  `query:"target_id"`
+	From       string `query:"from" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	To         string `query:"to" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	Page       int    `query:"page"`
+	Limit      int    `query:"limit"`
+}
+
+type AuditLogResponse struct {
+	ID             uint64          `json:"id"`
+	ActorID        *uint           `json:"actor_id"`
+	ImpersonatorID *uint           `json:"impersonator_id,omitempty"`
+	Action         string          `json:"action"`
+	TargetType     string          `json:"target_type"`
+	TargetID       uint            `json:"target_id"`
+	Changes        json.RawMessage `json:"changes"`
+	IP             string          `json:"ip"`
+	RequestID      string          `json:"request_id"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
@@ -1,5 +1,27 @@
 package model
 
 type Auth struct {
-	ID uint
-}
\ No newline at end of file
+	ID   uint
+	Role string
+	// APIKeyID dan Scopes hanya terisi jika request diautentikasi dengan API key.
+	// Scopes bernilai nil untuk access token JWT, yang berarti tidak dibatasi scope.
+	APIKeyID uint
+	Scopes   []string
+	// IP dan RequestID diisi middleware untuk dicatat di audit log
+	IP        string
+	RequestID string
+	// ImpersonatorID adalah ID admin jika request memakai token impersonation; ID dan Role milik user yang ditiru
+	ImpersonatorID uint
+}
+
+func (a *Auth) HasScope(scope string) bool {
+	if a.Scopes == nil {
+		return true
+	}
+	for _, s := range a.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -1 +1,7 @@
-package model
\ No newline at end of file
+package model
+
+type CategoryResponse struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+type CommentResponse struct {
+	ID        uint          `json:"id"`
+	Content   string        `json:"content"`
+	PostID    uint          `json:"postId"`
+	Author    AuthorSummary `json:"author"`
+	CreatedAt *time.Time    `json:"createdAt"`
+	UpdatedAt *time.Time    `json:"updatedAt"`
+}
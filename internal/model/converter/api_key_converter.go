@@ -0,0 +1,19 @@
+package converter
+
+import (
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+)
+
+func APIKeyToResponse(key *entity.APIKey) *model.APIKeyResponse {
+	return &model.APIKeyResponse{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.ScopeList(),
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
@@ -0,0 +1,23 @@
+package converter
+
+import (
+	"encoding/json"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+)
+
+func AuditLogToResponse(log *entity.AuditLog) *model.AuditLogResponse {
+	return &model.AuditLogResponse{
+		ID:             log.ID,
+		ActorID:        log.ActorID,
+		ImpersonatorID: log.ImpersonatorID,
+		Action:         log.Action,
+		TargetType:     log.TargetType,
+		TargetID:       log.TargetID,
+		Changes:        json.RawMessage(log.Changes),
+		IP:             log.IP,
+		RequestID:      log.RequestID,
+		CreatedAt:      log.CreatedAt,
+	}
+}
@@ -0,0 +1,22 @@
+package converter
+
+import (
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+)
+
+func CategoryToResponse(category *entity.Category) *model.CategoryResponse {
+	return &model.CategoryResponse{
+		ID:   category.ID,
+		Name: category.Name,
+		Slug: category.Slug,
+	}
+}
+
+func CategoriesToResponses(categories []entity.Category) []model.CategoryResponse {
+	responses := make([]model.CategoryResponse, len(categories))
+	for i := range categories {
+		responses[i] = *CategoryToResponse(&categories[i])
+	}
+	return responses
+}
@@ -0,0 +1,25 @@
+package converter
+
+import (
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+)
+
+func CommentToResponse(comment *entity.Comment) *model.CommentResponse {
+	return &model.CommentResponse{
+		ID:        comment.ID,
+		Content:   comment.Content,
+		PostID:    comment.PostID,
+		Author:    UserToAuthorSummary(comment.AuthorID, &comment.Author),
+		CreatedAt: comment.CreatedAt,
+		UpdatedAt: comment.UpdatedAt,
+	}
+}
+
+func CommentsToResponses(comments []entity.Comment) []model.CommentResponse {
+	responses := make([]model.CommentResponse, len(comments))
+	for i := range comments {
+		responses[i] = *CommentToResponse(&comments[i])
+	}
+	return responses
+}
@@ -0,0 +1,19 @@
+package converter
+
+import (
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+)
+
+func InvitationToResponse(invitation *entity.Invitation) *model.InvitationResponse {
+	return &model.InvitationResponse{
+		ID:        invitation.ID,
+		Role:      string(invitation.Role),
+		MaxUses:   invitation.MaxUses,
+		UsedCount: invitation.UsedCount,
+		ExpiresAt: invitation.ExpiresAt,
+		CreatedBy: invitation.CreatedBy,
+		RevokedAt: invitation.RevokedAt,
+		CreatedAt: invitation.CreatedAt,
+	}
+}
@@ -0,0 +1,62 @@
+package converter
+
+import (
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+)
+
+func PostToResponse(post *entity.Post) *model.PostResponse {
+	return &model.PostResponse{
+		ID:          post.ID,
+		Title:       post.Title,
+		Slug:        post.Slug,
+		Content:     post.Content,
+		Author:      UserToAuthorSummary(post.AuthorID, &post.Author),
+		Status:      string(post.Status),
+		PublishedAt: post.PublishedAt,
+		Categories:  CategoriesToResponses(post.Categories),
+		CreatedAt:   post.CreatedAt,
+		UpdatedAt:   post.UpdatedAt,
+	}
+}
+
+func PostsToResponses(posts []entity.Post) []model.PostResponse {
+	responses := make([]model.PostResponse, len(posts))
+	for i := range posts {
+		responses[i] = *PostToResponse(&posts[i])
+	}
+	return responses
+}
+
+func PostRevisionToResponse(revision *entity.PostRevision) *model.PostRevisionResponse {
+	var editor *model.AuthorSummary
+	if revision.EditorID != nil && revision.Editor != nil {
+		summary := UserToAuthorSummary(*revision.EditorID, revision.Editor)
+		editor = &summary
+	}
+
+	return &model.PostRevisionResponse{
+		Revision:      revision.Revision,
+		Title:         revision.Title,
+		Content:       revision.Content,
+		CategoryNames: revision.Categories,
+		Editor:        editor,
+		CreatedAt:     revision.CreatedAt,
+	}
+}
+
+func PostRevisionsToResponses(revisions []entity.PostRevision) []model.PostRevisionResponse {
+	responses := make([]model.PostRevisionResponse, len(revisions))
+	for i := range revisions {
+		responses[i] = *PostRevisionToResponse(&revisions[i])
+	}
+	return responses
+}
+
+func PostSearchResultToResponse(post *entity.Post, rank float64, headline string) *model.PostSearchResponse {
+	return &model.PostSearchResponse{
+		PostResponse: *PostToResponse(post),
+		Rank:         rank,
+		Snippet:      headline,
+	}
+}
@@ -0,0 +1,27 @@
+package converter
+
+import (
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+)
+
+func UserToProfileResponse(user *entity.User) *model.ProfileResponse {
+	return &model.ProfileResponse{
+		Username:    user.Username,
+		DisplayName: user.DisplayName,
+		Bio:         user.Bio,
+		Website:     user.Website,
+		AvatarURL:   user.AvatarURL,
+		JoinedAt:    user.CreatedAt,
+	}
+}
+
+func PostToAuthorPostResponse(post *entity.Post) *model.AuthorPostResponse {
+	return &model.AuthorPostResponse{
+		ID:          post.ID,
+		Title:       post.Title,
+		Slug:        post.Slug,
+		PublishedAt: post.PublishedAt,
+		Categories:  CategoriesToResponses(post.Categories),
+	}
+}
@@ -0,0 +1,17 @@
+package converter
+
+import (
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+)
+
+func SessionToResponse(session *entity.Session, currentSessionID string) *model.SessionResponse {
+	return &model.SessionResponse{
+		ID:           session.ID,
+		UserAgent:    session.UserAgent,
+		IP:           session.IP,
+		CreatedAt:    session.CreatedAt,
+		LastActiveAt: session.LastActiveAt,
+		Current:      session.ID == currentSessionID,
+	}
+}
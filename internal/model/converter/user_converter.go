@@ -14,6 +14,41 @@ func UserToResponse(user *entity.User) *model.UserResponse {
 	}
 }
 
+func UserToDetailResponse(user *entity.User) *model.UserDetailResponse {
+	return &model.UserDetailResponse{
+		ID:               user.ID,
+		Username:         user.Username,
+		Email:            user.Email,
+		Role:             string(user.Role),
+		EmailVerifiedAt:  user.EmailVerifiedAt,
+		TwoFactorEnabled: user.TwoFactorEnabled(),
+		DisplayName:      user.DisplayName,
+		Bio:              user.Bio,
+		Website:          user.Website,
+		AvatarURL:        user.AvatarURL,
+		CreatedAt:        user.CreatedAt,
+		UpdatedAt:        user.UpdatedAt,
+	}
+}
+
+func UsersToDetailResponses(users []entity.User) []model.UserDetailResponse {
+	responses := make([]model.UserDetailResponse, len(users))
+	for i := range users {
+		responses[i] = *UserToDetailResponse(&users[i])
+	}
+	return responses
+}
+
+// UserToAuthorSummary menerima id terpisah karena relasi Author tidak selalu di-preload, misalnya sesaat setelah post dibuat
+func UserToAuthorSummary(id uint, user *entity.User) model.AuthorSummary {
+	return model.AuthorSummary{
+		ID:          id,
+		Username:    user.Username,
+		DisplayName: user.DisplayName,
+		AvatarURL:   user.AvatarURL,
+	}
+}
+
 func UserToEvent(user *entity.User) *model.UserEvent {
 	return &model.UserEvent{
 		ID:        user.ID,
@@ -0,0 +1,6 @@
+package model
+
+// Event adalah payload yang dikirim ke subscriber lewat gateway/messaging
+type Event interface {
+	GetId() uint
+}
@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+type ImpersonationResponse struct {
+	Token          string    `json:"token"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	UserID         uint      `json:"user_id"`
+	Username       string    `json:"username"`
+	ImpersonatorID uint      `json:"impersonator_id"`
+}
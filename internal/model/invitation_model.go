@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+type RegistrationModeRequest struct {
+	Mode string `json:"mode" validate:"required,oneof=open invite_only closed"`
+}
+
+type RegistrationModeResponse struct {
+	Mode string `json:"mode"`
+}
+
+// CreateInvitationRequest tanpa expires_at memakai registration.invitation_expiration dari config
+type CreateInvitationRequest struct {
+	Role      string     `json:"role" validate:"required,user_role"`
+	MaxUses   int        `json:"max_uses" validate:"required,min=1,max=1000"`
+	ExpiresAt *time.Time `json:"expires_at" validate:"omitempty"`
+}
+
+type InvitationResponse struct {
+	ID        uint       `json:"id"`
+	Code      string     `json:"code,omitempty"`
+	Role      string     `json:"role"`
+	MaxUses   int        `json:"max_uses"`
+	UsedCount int        `json:"used_count"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	CreatedBy *uint      `json:"created_by,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
@@ -16,4 +16,21 @@ type PageMetadata struct {
 	Size int `json:"size"`
 	TotalItem int `json:"totalItem"`
 	TotalPage int `json:"totalPage"`
-}
\ No newline at end of file
+}
+
+// NewPageMetadata menghitung jumlah halaman dari total item; size 0 dianggap satu halaman agar tidak membagi dengan nol
+func NewPageMetadata(page, size int, total int64) *PageMetadata {
+	totalPage := 0
+	if size > 0 {
+		totalPage = int((total + int64(size) - 1) / int64(size))
+	} else if total > 0 {
+		totalPage = 1
+	}
+
+	return &PageMetadata{
+		Page:      page,
+		Size:      size,
+		TotalItem: int(total),
+		TotalPage: totalPage,
+	}
+}
@@ -0,0 +1,8 @@
+package model
+
+type OIDCCallbackRequest struct {
+	Provider string `validate:"required,max=50"`
+	Code     string `validate:"required,max=2048"`
+	State    string `validate:"required,max=100"`
+	ClientInfo
+}
@@ -0,0 +1,15 @@
+package model
+
+type PasswordHashParamsCount struct {
+	Params  string `json:"params"`
+	Count   int64  `json:"count"`
+	Current bool   `json:"current"`
+}
+
+// PasswordHashReportResponse dipakai untuk memantau berapa akun yang belum di-rehash ke parameter argon2id terbaru
+type PasswordHashReportResponse struct {
+	Current  string                    `json:"current"`
+	Total    int64                     `json:"total"`
+	Outdated int64                     `json:"outdated"`
+	Params   []PasswordHashParamsCount `json:"params"`
+}
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// PostEvent dikirim ke channel messaging.PostPublishedChannel setiap kali post terbit
+type PostEvent struct {
+	ID          uint       `json:"id"`
+	Title       string     `json:"title"`
+	Slug        string     `json:"slug"`
+	AuthorID    uint       `json:"author_id"`
+	PublishedAt *time.Time `json:"published_at"`
+}
+
+func (p *PostEvent) GetId() uint {
+	return p.ID
+}
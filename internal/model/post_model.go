@@ -4,13 +4,74 @@ import "time"
 
 type CreatePostRequest struct {
 	Title   string   `json:"title" validate:"required,min=5,max=255"`
-	Content string   `json:"content" validate:"required,min=10"`
+	Content string   `json:"content" validate:"required,min=10,max=100000"`
 	CategoryNames    []string `json:"categoryNames" validate:"required,min=1"`
 }
 
 type UpdatePostRequest struct {
-	Title         *string    `json:"title" validate:"omitempty,min=5,max=255"`
-	Content       *string    `json:"content" validate:"omitempty,min=10"`
-	PublishedAt   *time.Time `json:"publishedAt"`
-	CategoryNames *[]string  `json:"categoryNames" validate:"omitempty,dive,min=1,max=50"` // Pointer ke slice
+	Title         *string   `json:"title" validate:"omitempty,min=5,max=255"`
+	Content       *string   `json:"content" validate:"omitempty,min=10,max=100000"`
+	CategoryNames *[]string `json:"categoryNames" validate:"omitempty,dive,min=1,max=50"` // Pointer ke slice
+}
+
+type PostResponse struct {
+	ID          uint               `json:"id"`
+	Title       string             `json:"title"`
+	Slug        string             `json:"slug"`
+	Content     string             `json:"content"`
+	Author      AuthorSummary      `json:"author"`
+	Status      string             `json:"status"`
+	PublishedAt *time.Time         `json:"publishedAt"`
+	Categories  []CategoryResponse `json:"categories"`
+	CreatedAt   *time.Time         `json:"createdAt"`
+	UpdatedAt   *time.Time         `json:"updatedAt"`
+}
+
+// PublishPostRequest menerbitkan post sekarang, atau menjadwalkannya jika publishAt di masa depan
+type PublishPostRequest struct {
+	PublishAt *time.Time `json:"publishAt"`
+}
+
+type PostRevisionResponse struct {
+	Revision      int            `json:"revision"`
+	Title         string         `json:"title"`
+	Content       string         `json:"content"`
+	CategoryNames []string       `json:"categoryNames"`
+	Editor        *AuthorSummary `json:"editor"`
+	CreatedAt     time.Time      `json:"createdAt"`
+}
+
+// PostRevisionDiffResponse berisi unified diff dari revisi From ke revisi To
+type PostRevisionDiffResponse struct {
+	From int    `json:"from"`
+	To   int    `json:"to"`
+	Diff string `json:"diff"`
+}
+
+// SearchPostRequest memakai lang untuk memilih konfigurasi text search: en (english) atau id (indonesian)
+type SearchPostRequest struct {
+	Query    string `query:"q" validate:"required,min=2,max=200"`
+	Language string `query:"lang" validate:"omitempty,oneof=en id"`
+	Page     int    `query:"page"`
+	Limit    int    `query:"limit"`
+}
+
+// PostSearchResponse adalah post beserta skor relevansi dan cuplikan konten dengan kata yang cocok ditandai <mark>
+type PostSearchResponse struct {
+	PostResponse
+	Rank    float64 `json:"rank"`
+	Snippet string  `json:"snippet"`
+}
+
+// ListPostRequest adalah filter daftar post. Sort berisi field dipisah koma dengan awalan "-" untuk descending,
+// misalnya "-published_at,title". Filter status selain published hanya boleh dipakai admin.
+type ListPostRequest struct {
+	Category      string `query:"category" validate:"omitempty,max=100"`
+	Author        string `query:"author" validate:"omitempty,max=50"`
+	Status        string `query:"status" validate:"omitempty,oneof=draft scheduled published archived"`
+	PublishedFrom string `query:"published_from" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	PublishedTo   string `query:"published_to" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	Sort          string `query:"sort" validate:"omitempty,max=100"`
+	Page          int    `query:"page"`
+	Limit         int    `query:"limit"`
 }
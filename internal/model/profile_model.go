@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// UpdateProfileRequest memakai pointer agar field yang tidak dikirim tidak diubah; string kosong menghapus nilainya
+type UpdateProfileRequest struct {
+	DisplayName *string `json:"display_name" validate:"omitempty,max=100"`
+	Bio         *string `json:"bio" validate:"omitempty,max=500"`
+	Website     *string `json:"website" validate:"omitempty,max=255,optional_http_url"`
+	AvatarURL   *string `json:"avatar_url" validate:"omitempty,max=255,optional_http_url"`
+}
+
+// ProfileResponse hanya berisi field yang aman ditampilkan ke publik
+type ProfileResponse struct {
+	Username    string     `json:"username"`
+	DisplayName string     `json:"display_name"`
+	Bio         string     `json:"bio"`
+	Website     string     `json:"website"`
+	AvatarURL   string     `json:"avatar_url"`
+	JoinedAt    *time.Time `json:"joined_at,omitempty"`
+}
+
+type AuthorPostResponse struct {
+	ID          uint               `json:"id"`
+	Title       string             `json:"title"`
+	Slug        string             `json:"slug"`
+	PublishedAt *time.Time         `json:"publishedAt"`
+	Categories  []CategoryResponse `json:"categories"`
+}
+
+type AuthorResponse struct {
+	ProfileResponse
+	Posts PageResponse[AuthorPostResponse] `json:"posts"`
+}
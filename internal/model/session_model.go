@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// ClientInfo diisi controller dari request HTTP dan dicatat pada sesi yang dibuat saat login
+type ClientInfo struct {
+	IP        string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+type SessionResponse struct {
+	ID           string    `json:"id"`
+	UserAgent    string    `json:"user_agent"`
+	IP           string    `json:"ip"`
+	CreatedAt    time.Time `json:"created_at"`
+	LastActiveAt time.Time `json:"last_active_at"`
+	Current      bool      `json:"current"`
+}
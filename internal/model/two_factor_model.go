@@ -0,0 +1,41 @@
+package model
+
+type TwoFactorEnrollResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type TwoFactorRecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type TwoFactorEnableRequest struct {
+	Code       string `json:"code" validate:"required,numeric,len=6"`
+	ClientInfo `json:"-"`
+}
+
+// TwoFactorDisableRequest membutuhkan kode TOTP atau salah satu kode pemulihan
+type TwoFactorDisableRequest struct {
+	Code         string `json:"code" validate:"required_without=RecoveryCode,omitempty,numeric,len=6"`
+	RecoveryCode string `json:"recovery_code" validate:"omitempty,max=20"`
+	ClientInfo   `json:"-"`
+}
+
+type TwoFactorSetupRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required,max=100"`
+}
+
+type TwoFactorVerifyRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required,max=100"`
+	Code           string `json:"code" validate:"required_without=RecoveryCode,omitempty,numeric,len=6"`
+	RecoveryCode   string `json:"recovery_code" validate:"omitempty,max=20"`
+	ClientInfo     `json:"-"`
+}
+
+type TwoFactorRequiredRolesRequest struct {
+	Roles []string `json:"roles" validate:"required,dive,user_role"`
+}
+
+type TwoFactorRequiredRolesResponse struct {
+	Roles []string `json:"roles"`
+}
@@ -3,37 +3,93 @@ package model
 import "time"
 
 type UserResponse struct {
-	ID        uint       `json:"id,omitempty"`
-	Username  string     `json:"username,omitempty"`
-	Token     string     `json:"token,omitempty"`
-	CreatedAt *time.Time `json:"created_at,omitempty"`
-	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	ID           uint       `json:"id,omitempty"`
+	Username     string     `json:"username,omitempty"`
+	Token        string     `json:"token,omitempty"`
+	RefreshToken string     `json:"refresh_token,omitempty"`
+	CreatedAt    *time.Time `json:"created_at,omitempty"`
+	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+
+	// Diisi sebagai pengganti Token saat login membutuhkan langkah 2FA
+	TwoFactorRequired      bool     `json:"two_factor_required,omitempty"`
+	TwoFactorSetupRequired bool     `json:"two_factor_setup_required,omitempty"`
+	ChallengeToken         string   `json:"challenge_token,omitempty"`
+	RecoveryCodes          []string `json:"recovery_codes,omitempty"`
+}
+
+// AuthorSummary adalah identitas publik penulis yang disematkan pada post dan komentar
+type AuthorSummary struct {
+	ID          uint   `json:"id"`
+	Username    string `json:"username"`
+	DisplayName string `json:"display_name"`
+	AvatarURL   string `json:"avatar_url"`
+}
+
+// UserDetailResponse dipakai endpoint /users dan /auth/me; hanya pemilik akun dan admin yang dapat mengaksesnya
+type UserDetailResponse struct {
+	ID               uint       `json:"id"`
+	Username         string     `json:"username"`
+	Email            string     `json:"email"`
+	Role             string     `json:"role"`
+	EmailVerifiedAt  *time.Time `json:"email_verified_at"`
+	TwoFactorEnabled bool       `json:"two_factor_enabled"`
+	DisplayName      string     `json:"display_name"`
+	Bio              string     `json:"bio"`
+	Website          string     `json:"website"`
+	AvatarURL        string     `json:"avatar_url"`
+	CreatedAt        *time.Time `json:"created_at"`
+	UpdatedAt        *time.Time `json:"updated_at"`
 }
 
 type VerifyUserRequest struct {
 	Token string `validate:"required,max=100"`
 }
 
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"required,email,max=100"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email,max=100"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" validate:"required,max=100"`
+	Password string `json:"password" validate:"required,max=100"`
+}
+
 type RegisterUserRequest struct {
 	Email    string `json:"email" validate:"required,max=100"`
 	Password string `json:"password" validate:"required,max=100"`
 	Username string `json:"username" validate:"required,max=100"`
+	// InvitationCode wajib pada mode registrasi invite_only
+	InvitationCode string `json:"invitation_code" validate:"omitempty,max=100"`
 }
 
 type UpdateUserRequest struct {
 	Username *string `json:"username,omitempty" validate:"omitempty,min=3,max=50"`
 	Email    *string `json:"email,omitempty" validate:"omitempty,email"`
-	Password *string `json:"password,omitempty" validate:"omitempty,min=8"`
-	Role     *string `json:"role,omitempty" validate:"omitempty,oneof=user admin"`
+	Password *string `json:"password,omitempty" validate:"omitempty,max=100"`
+	Role     *string `json:"role,omitempty" validate:"omitempty,user_role"`
 }
 
 type LoginUserRequest struct {
-	Email    string `json:"email" validate:"required,max=100"`
-	Password string `json:"password" validate:"required,max=100"`
+	Email      string `json:"email" validate:"required,max=100"`
+	Password   string `json:"password" validate:"required,max=100"`
+	ClientInfo `json:"-"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required,max=255"`
+	ClientInfo   `json:"-"`
 }
 
 type LogoutUserRequest struct {
-	Email string `json:"email" validate:"required,max=100"`
+	UserID       uint      `json:"-"`
+	TokenID      string    `json:"-"`
+	SessionID    string    `json:"-"`
+	ExpiresAt    time.Time `json:"-"`
+	RefreshToken string    `json:"refresh_token" validate:"omitempty,max=255"`
 }
 
 type GetUserRequest struct {
@@ -0,0 +1,147 @@
+package policy
+
+import (
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
+)
+
+type Action string
+
+const (
+	ActionCreate     Action = "create"
+	ActionUpdate     Action = "update"
+	ActionDelete     Action = "delete"
+	ActionChangeRole Action = "change_role"
+	ActionUnlock     Action = "unlock"
+)
+
+type ResourceType string
+
+const (
+	ResourcePost     ResourceType = "post"
+	ResourceComment  ResourceType = "comment"
+	ResourceCategory ResourceType = "category"
+	ResourceUser     ResourceType = "user"
+)
+
+// Resource menggambarkan objek yang diakses. OwnerID adalah pemilik resource itu sendiri,
+// sedangkan ParentOwnerID adalah pemilik resource induknya (misalnya penulis postingan dari sebuah komentar).
+type Resource struct {
+	Type          ResourceType
+	OwnerID       uint
+	ParentOwnerID uint
+}
+
+// Rule mengizinkan aksi jika role, aksi, dan tipe resource cocok serta Condition terpenuhi.
+// Field yang kosong berarti "semua".
+type Rule struct {
+	Description string
+	Roles       []entity.UserRole
+	Actions     []Action
+	Resources   []ResourceType
+	Condition   func(subject *model.Auth, resource Resource) bool
+}
+
+func IsOwner(subject *model.Auth, resource Resource) bool {
+	return resource.OwnerID != 0 && resource.OwnerID == subject.ID
+}
+
+func IsParentOwner(subject *model.Auth, resource Resource) bool {
+	return resource.ParentOwnerID != 0 && resource.ParentOwnerID == subject.ID
+}
+
+var DefaultRules = []Rule{
+	{
+		Description: "admin dapat melakukan apa saja",
+		Roles:       []entity.UserRole{entity.UserRoleAdmin},
+	},
+	{
+		Description: "author dapat membuat postingan",
+		Roles:       []entity.UserRole{entity.UserRoleAuthor},
+		Actions:     []Action{ActionCreate},
+		Resources:   []ResourceType{ResourcePost},
+	},
+	{
+		Description: "author dapat mengubah dan menghapus postingannya sendiri",
+		Roles:       []entity.UserRole{entity.UserRoleAuthor},
+		Actions:     []Action{ActionUpdate, ActionDelete},
+		Resources:   []ResourceType{ResourcePost},
+		Condition:   IsOwner,
+	},
+	{
+		Description: "semua pengguna dapat berkomentar",
+		Actions:     []Action{ActionCreate},
+		Resources:   []ResourceType{ResourceComment},
+	},
+	{
+		Description: "pengguna dapat mengubah dan menghapus komentarnya sendiri",
+		Actions:     []Action{ActionUpdate, ActionDelete},
+		Resources:   []ResourceType{ResourceComment},
+		Condition:   IsOwner,
+	},
+	{
+		Description: "pemilik postingan dapat menghapus komentar di postingannya",
+		Actions:     []Action{ActionDelete},
+		Resources:   []ResourceType{ResourceComment},
+		Condition:   IsParentOwner,
+	},
+	{
+		Description: "pengguna dapat mengubah dan menghapus akunnya sendiri",
+		Actions:     []Action{ActionUpdate, ActionDelete},
+		Resources:   []ResourceType{ResourceUser},
+		Condition:   IsOwner,
+	},
+}
+
+type Engine struct {
+	rules []Rule
+}
+
+func NewEngine(rules ...Rule) *Engine {
+	return &Engine{rules: rules}
+}
+
+// Can mengembalikan true jika minimal satu rule mengizinkan subject melakukan action pada resource
+func (e *Engine) Can(subject *model.Auth, action Action, resource Resource) bool {
+	if subject == nil {
+		return false
+	}
+
+	for _, rule := range e.rules {
+		if rule.matches(subject, action, resource) {
+			return true
+		}
+	}
+	return false
+}
+
+// Authorize sama dengan Can, tetapi mengembalikan utils.ErrForbidden saat ditolak
+func (e *Engine) Authorize(subject *model.Auth, action Action, resource Resource) error {
+	if !e.Can(subject, action, resource) {
+		return utils.ErrForbidden("Anda tidak memiliki izin untuk melakukan " + string(action) + " pada " + string(resource.Type) + " ini")
+	}
+	return nil
+}
+
+func (r Rule) matches(subject *model.Auth, action Action, resource Resource) bool {
+	if len(r.Roles) > 0 && !contains(r.Roles, entity.UserRole(subject.Role)) {
+		return false
+	}
+	if len(r.Actions) > 0 && !contains(r.Actions, action) {
+		return false
+	}
+	if len(r.Resources) > 0 && !contains(r.Resources, resource.Type) {
+		return false
+	}
+	return r.Condition == nil || r.Condition(subject, resource)
+}
+
+func contains[T comparable](values []T, value T) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
+)
+
+func TestDefaultRules(t *testing.T) {
+	admin := &model.Auth{ID: 1, Role: string(entity.UserRoleAdmin)}
+	author := &model.Auth{ID: 2, Role: string(entity.UserRoleAuthor)}
+	reader := &model.Auth{ID: 3, Role: string(entity.UserRoleReader)}
+
+	tests := []struct {
+		name     string
+		subject  *model.Auth
+		action   Action
+		resource Resource
+		want     bool
+	}{
+		{"tanpa subject", nil, ActionCreate, Resource{Type: ResourceComment}, false},
+
+		{"admin mengubah role user", admin, ActionChangeRole, Resource{Type: ResourceUser, OwnerID: 3}, true},
+		{"admin membuka kunci user", admin, ActionUnlock, Resource{Type: ResourceUser, OwnerID: 3}, true},
+		{"admin menghapus postingan orang lain", admin, ActionDelete, Resource{Type: ResourcePost, OwnerID: 2}, true},
+		{"admin membuat kategori", admin, ActionCreate, Resource{Type: ResourceCategory}, true},
+
+		{"author membuat postingan", author, ActionCreate, Resource{Type: ResourcePost}, true},
+		{"author mengubah postingannya sendiri", author, ActionUpdate, Resource{Type: ResourcePost, OwnerID: 2}, true},
+		{"author menghapus postingannya sendiri", author, ActionDelete, Resource{Type: ResourcePost, OwnerID: 2}, true},
+		{"author mengubah postingan orang lain", author, ActionUpdate, Resource{Type: ResourcePost, OwnerID: 1}, false},
+		{"author mengubah postingan tanpa pemilik", author, ActionUpdate, Resource{Type: ResourcePost}, false},
+		{"author membuat kategori", author, ActionCreate, Resource{Type: ResourceCategory}, false},
+		{"author mengubah role", author, ActionChangeRole, Resource{Type: ResourceUser, OwnerID: 2}, false},
+
+		{"reader membuat postingan", reader, ActionCreate, Resource{Type: ResourcePost}, false},
+		{"reader mengubah postingan", reader, ActionUpdate, Resource{Type: ResourcePost, OwnerID: 3}, false},
+		{"reader berkomentar", reader, ActionCreate, Resource{Type: ResourceComment}, true},
+		{"reader mengubah komentarnya sendiri", reader, ActionUpdate, Resource{Type: ResourceComment, OwnerID: 3}, true},
+		{"reader menghapus komentar orang lain", reader, ActionDelete, Resource{Type: ResourceComment, OwnerID: 2}, false},
+
+		{"pemilik postingan menghapus komentar di postingannya", author, ActionDelete, Resource{Type: ResourceComment, OwnerID: 3, ParentOwnerID: 2}, true},
+		{"pemilik postingan mengubah komentar di postingannya", author, ActionUpdate, Resource{Type: ResourceComment, OwnerID: 3, ParentOwnerID: 2}, false},
+
+		{"user mengubah akunnya sendiri", reader, ActionUpdate, Resource{Type: ResourceUser, OwnerID: 3}, true},
+		{"user menghapus akunnya sendiri", reader, ActionDelete, Resource{Type: ResourceUser, OwnerID: 3}, true},
+		{"user mengubah akun orang lain", reader, ActionUpdate, Resource{Type: ResourceUser, OwnerID: 2}, false},
+		{"user mengubah role sendiri", reader, ActionChangeRole, Resource{Type: ResourceUser, OwnerID: 3}, false},
+	}
+
+	engine := NewEngine(DefaultRules...)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := engine.Can(tt.subject, tt.action, tt.resource); got != tt.want {
+				t.Errorf("Can() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	engine := NewEngine(DefaultRules...)
+	reader := &model.Auth{ID: 3, Role: string(entity.UserRoleReader)}
+
+	if err := engine.Authorize(reader, ActionCreate, Resource{Type: ResourceComment}); err != nil {
+		t.Fatalf("Authorize() error = %v, want nil", err)
+	}
+
+	if err := engine.Authorize(reader, ActionCreate, Resource{Type: ResourcePost}); !utils.IsErrForbidden(err) {
+		t.Fatalf("Authorize() error = %v, want ErrForbidden", err)
+	}
+}
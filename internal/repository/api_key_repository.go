@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"gorm.io/gorm"
+)
+
+type APIKeyRepository interface {
+	Create(key *entity.APIKey) error
+	FindByPrefix(prefix string) (*entity.APIKey, error)
+	FindByUserID(userID uint) ([]entity.APIKey, error)
+	Revoke(id, userID uint) (bool, error)
+	TouchLastUsed(id uint, usedAt time.Time) error
+}
+
+type apiKeyRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) APIKeyRepository {
+	return &apiKeyRepositoryImpl{db: db}
+}
+
+func (r *apiKeyRepositoryImpl) Create(key *entity.APIKey) error {
+	return r.db.Create(key).Error
+}
+
+func (r *apiKeyRepositoryImpl) FindByPrefix(prefix string) (*entity.APIKey, error) {
+	var key entity.APIKey
+	err := r.db.Where("prefix = ?", prefix).First(&key).Error
+	return &key, err
+}
+
+func (r *apiKeyRepositoryImpl) FindByUserID(userID uint) ([]entity.APIKey, error) {
+	var keys []entity.APIKey
+	err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&keys).Error
+	return keys, err
+}
+
+// Revoke mengembalikan false jika key tidak ditemukan, bukan milik user, atau sudah dicabut
+func (r *apiKeyRepositoryImpl) Revoke(id, userID uint) (bool, error) {
+	result := r.db.Model(&entity.APIKey{}).Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).Update("revoked_at", time.Now())
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *apiKeyRepositoryImpl) TouchLastUsed(id uint, usedAt time.Time) error {
+	return r.db.Model(&entity.APIKey{}).Where("id = ?", id).Update("last_used_at", usedAt).Error
+}
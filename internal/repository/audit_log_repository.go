@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"gorm.io/gorm"
+)
+
+type AuditLogFilter struct {
+	ActorID    *uint
+	Action     string
+	TargetType string
+	TargetID   *uint
+	From       *time.Time
+	To         *time.Time
+}
+
+type AuditLogRepository interface {
+	Create(db *gorm.DB, log *entity.AuditLog) error
+	FindAll(filter AuditLogFilter, offset, limit int) ([]entity.AuditLog, int64, error)
+}
+
+type auditLogRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAuditLogRepository(db *gorm.DB) AuditLogRepository {
+	return &auditLogRepositoryImpl{db: db}
+}
+
+func (r *auditLogRepositoryImpl) Create(db *gorm.DB, log *entity.AuditLog) error {
+	return db.Create(log).Error
+}
+
+func (r *auditLogRepositoryImpl) FindAll(filter AuditLogFilter, offset, limit int) ([]entity.AuditLog, int64, error) {
+	spec := &QuerySpec{Offset: offset, Limit: limit}
+	if filter.ActorID != nil {
+		spec.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.Action != "" {
+		spec.Where("action = ?", filter.Action)
+	}
+	if filter.TargetType != "" {
+		spec.Where("target_type = ?", filter.TargetType)
+	}
+	if filter.TargetID != nil {
+		spec.Where("target_id = ?", *filter.TargetID)
+	}
+	if filter.From != nil {
+		spec.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		spec.Where("created_at < ?", *filter.To)
+	}
+	spec.OrderBy(SortField{Column: "created_at", Desc: true}, SortField{Column: "id", Desc: true})
+
+	var logs []entity.AuditLog
+	total, err := FindPage(r.db, spec, &logs)
+	return logs, total, err
+}
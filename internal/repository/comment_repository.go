@@ -11,6 +11,8 @@ type CommentRepository interface {
 	FindByID(id uint) (*entity.Comment, error)
 	Update(comment *entity.Comment) error
 	Delete(id uint) error
+	ReassignAuthor(db *gorm.DB, fromID, toID uint) (int64, error)
+	DeleteByAuthorID(db *gorm.DB, authorID uint) (int64, error)
 }
 
 type commentRepositoryImpl struct {
@@ -43,4 +45,15 @@ func (r *commentRepositoryImpl) Update(comment *entity.Comment) error {
 
 func (r *commentRepositoryImpl) Delete(id uint) error {
 	return r.db.Delete(&entity.Comment{}, id).Error
-}
\ No newline at end of file
+}
+
+// ReassignAuthor menganonimkan komentar dengan memindahkannya ke user lain, biasanya user ghost
+func (r *commentRepositoryImpl) ReassignAuthor(db *gorm.DB, fromID, toID uint) (int64, error) {
+	result := db.Model(&entity.Comment{}).Where("author_id = ?", fromID).Update("author_id", toID)
+	return result.RowsAffected, result.Error
+}
+
+func (r *commentRepositoryImpl) DeleteByAuthorID(db *gorm.DB, authorID uint) (int64, error) {
+	result := db.Where("author_id = ?", authorID).Delete(&entity.Comment{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"gorm.io/gorm"
+)
+
+type EmailVerificationRepository interface {
+	Create(db *gorm.DB, token *entity.EmailVerificationToken) error
+	FindValidByHash(tokenHash string) (*entity.EmailVerificationToken, error)
+	DeleteByUserID(db *gorm.DB, userID uint) error
+}
+
+type emailVerificationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewEmailVerificationRepository(db *gorm.DB) EmailVerificationRepository {
+	return &emailVerificationRepositoryImpl{db: db}
+}
+
+func (r *emailVerificationRepositoryImpl) Create(db *gorm.DB, token *entity.EmailVerificationToken) error {
+	return db.Create(token).Error
+}
+
+// FindValidByHash hanya mengembalikan token yang belum kedaluwarsa
+func (r *emailVerificationRepositoryImpl) FindValidByHash(tokenHash string) (*entity.EmailVerificationToken, error) {
+	var token entity.EmailVerificationToken
+	err := r.db.Where("token_hash = ? AND expires_at > ?", tokenHash, time.Now()).First(&token).Error
+	return &token, err
+}
+
+func (r *emailVerificationRepositoryImpl) DeleteByUserID(db *gorm.DB, userID uint) error {
+	return db.Where("user_id = ?", userID).Delete(&entity.EmailVerificationToken{}).Error
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type InvitationRepository interface {
+	Create(invitation *entity.Invitation) error
+	FindAll() ([]entity.Invitation, error)
+	Consume(db *gorm.DB, codeHash string) (*entity.Invitation, bool, error)
+	Revoke(id uint) (bool, error)
+}
+
+type invitationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewInvitationRepository(db *gorm.DB) InvitationRepository {
+	return &invitationRepositoryImpl{db: db}
+}
+
+func (r *invitationRepositoryImpl) Create(invitation *entity.Invitation) error {
+	return r.db.Create(invitation).Error
+}
+
+func (r *invitationRepositoryImpl) FindAll() ([]entity.Invitation, error) {
+	var invitations []entity.Invitation
+	err := r.db.Order("created_at DESC").Find(&invitations).Error
+	return invitations, err
+}
+
+// Consume menaikkan used_count dengan satu UPDATE bersyarat sehingga dua registrasi bersamaan tidak dapat
+// melampaui max_uses. Hasil false berarti kode tidak ada, kedaluwarsa, dicabut, atau sudah habis dipakai.
+func (r *invitationRepositoryImpl) Consume(db *gorm.DB, codeHash string) (*entity.Invitation, bool, error) {
+	var invitation entity.Invitation
+	result := db.Model(&invitation).
+		Clauses(clause.Returning{}).
+		Where("code_hash = ? AND used_count < max_uses AND expires_at > ? AND revoked_at IS NULL", codeHash, time.Now()).
+		Update("used_count", gorm.Expr("used_count + 1"))
+	if result.Error != nil {
+		return nil, false, result.Error
+	}
+	return &invitation, result.RowsAffected == 1, nil
+}
+
+// Revoke mengembalikan false jika undangan tidak ditemukan atau sudah dicabut
+func (r *invitationRepositoryImpl) Revoke(id uint) (bool, error) {
+	result := r.db.Model(&entity.Invitation{}).Where("id = ? AND revoked_at IS NULL", id).Update("revoked_at", time.Now())
+	return result.RowsAffected == 1, result.Error
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	loginFailuresKeyPrefix = "login_failures:"
+	loginBlockKeyPrefix    = "login_block:"
+	loginLockoutKeyPrefix  = "login_lockout:"
+
+	// loginLockoutMarkerTTL menyimpan jejak lockout cukup lama agar pembukaan kunci berikutnya tetap tercatat di log
+	loginLockoutMarkerTTL = 24 * time.Hour
+)
+
+// registerLoginFailureScript menaikkan counter dan hanya memasang TTL pada kegagalan pertama,
+// sehingga window dihitung sejak percobaan gagal pertama dan tidak diperpanjang oleh penyerang.
+var registerLoginFailureScript = redis.NewScript(`
+local count = redis.call('INCR', KEYS[1])
+if count == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return count
+`)
+
+// LoginAttemptRepository mencatat percobaan login gagal per subjek (akun atau IP).
+// Subjek yang sedang diblokir, baik karena jeda progresif maupun lockout, ditolak sebelum password diperiksa.
+type LoginAttemptRepository interface {
+	RegisterFailure(ctx context.Context, subject string, window time.Duration) (int64, error)
+	Delay(ctx context.Context, subject string, delay time.Duration) error
+	Lock(ctx context.Context, subject string, duration time.Duration) error
+	BlockedFor(ctx context.Context, subjects ...string) (time.Duration, error)
+	Reset(ctx context.Context, subject string) (bool, error)
+}
+
+type loginAttemptRepositoryImpl struct {
+	redis *redis.Client
+}
+
+func NewLoginAttemptRepository(redis *redis.Client) LoginAttemptRepository {
+	return &loginAttemptRepositoryImpl{redis: redis}
+}
+
+func (r *loginAttemptRepositoryImpl) RegisterFailure(ctx context.Context, subject string, window time.Duration) (int64, error) {
+	return registerLoginFailureScript.Run(ctx, r.redis, []string{loginFailuresKeyPrefix + subject}, window.Milliseconds()).Int64()
+}
+
+func (r *loginAttemptRepositoryImpl) Delay(ctx context.Context, subject string, delay time.Duration) error {
+	return r.redis.Set(ctx, loginBlockKeyPrefix+subject, 1, delay).Err()
+}
+
+// Lock memblokir subjek dan mengosongkan counter, sehingga setelah lockout berakhir jeda progresif dimulai lagi dari awal
+func (r *loginAttemptRepositoryImpl) Lock(ctx context.Context, subject string, duration time.Duration) error {
+	pipe := r.redis.TxPipeline()
+	pipe.Set(ctx, loginBlockKeyPrefix+subject, 1, duration)
+	pipe.Set(ctx, loginLockoutKeyPrefix+subject, 1, loginLockoutMarkerTTL)
+	pipe.Del(ctx, loginFailuresKeyPrefix+subject)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// BlockedFor mengembalikan sisa waktu blokir terlama di antara subjek yang diberikan, atau 0 jika tidak ada yang diblokir
+func (r *loginAttemptRepositoryImpl) BlockedFor(ctx context.Context, subjects ...string) (time.Duration, error) {
+	pipe := r.redis.Pipeline()
+	cmds := make([]*redis.DurationCmd, len(subjects))
+	for i, subject := range subjects {
+		cmds[i] = pipe.PTTL(ctx, loginBlockKeyPrefix+subject)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+
+	var longest time.Duration
+	for _, cmd := range cmds {
+		if ttl := cmd.Val(); ttl > longest {
+			longest = ttl
+		}
+	}
+	return longest, nil
+}
+
+// Reset menghapus counter, blokir, dan jejak lockout. Nilai true berarti subjek pernah terkunci sebelumnya.
+func (r *loginAttemptRepositoryImpl) Reset(ctx context.Context, subject string) (bool, error) {
+	pipe := r.redis.TxPipeline()
+	pipe.Del(ctx, loginFailuresKeyPrefix+subject, loginBlockKeyPrefix+subject)
+	wasLocked := pipe.Del(ctx, loginLockoutKeyPrefix+subject)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, err
+	}
+	return wasLocked.Val() > 0, nil
+}
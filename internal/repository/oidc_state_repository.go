@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"github.com/redis/go-redis/v9"
+)
+
+var ErrOIDCStateNotFound = errors.New("state OIDC tidak ditemukan")
+
+const oidcStateKeyPrefix = "oidc_state:"
+
+// OIDCStateRepository menyimpan state, nonce, dan PKCE code verifier di antara redirect ke provider dan callback
+type OIDCStateRepository interface {
+	Save(ctx context.Context, state string, data *entity.OIDCState, ttl time.Duration) error
+	Consume(ctx context.Context, state string) (*entity.OIDCState, error)
+}
+
+type oidcStateRepositoryImpl struct {
+	redis *redis.Client
+}
+
+func NewOIDCStateRepository(redis *redis.Client) OIDCStateRepository {
+	return &oidcStateRepositoryImpl{redis: redis}
+}
+
+func (r *oidcStateRepositoryImpl) Save(ctx context.Context, state string, data *entity.OIDCState, ttl time.Duration) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return r.redis.Set(ctx, oidcStateKeyPrefix+state, payload, ttl).Err()
+}
+
+// Consume mengambil dan menghapus state dalam satu transaksi sehingga callback yang sama tidak dapat diputar ulang
+func (r *oidcStateRepositoryImpl) Consume(ctx context.Context, state string) (*entity.OIDCState, error) {
+	pipe := r.redis.TxPipeline()
+	get := pipe.Get(ctx, oidcStateKeyPrefix+state)
+	pipe.Del(ctx, oidcStateKeyPrefix+state)
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	payload, err := get.Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrOIDCStateNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data := new(entity.OIDCState)
+	if err := json.Unmarshal(payload, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
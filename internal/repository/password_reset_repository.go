@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"gorm.io/gorm"
+)
+
+type PasswordResetRepository interface {
+	Create(db *gorm.DB, token *entity.PasswordResetToken) error
+	FindValidByHash(tokenHash string) (*entity.PasswordResetToken, error)
+	MarkUsed(db *gorm.DB, id uint) (bool, error)
+	InvalidateByUserID(db *gorm.DB, userID uint) error
+}
+
+type passwordResetRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewPasswordResetRepository(db *gorm.DB) PasswordResetRepository {
+	return &passwordResetRepositoryImpl{db: db}
+}
+
+func (r *passwordResetRepositoryImpl) Create(db *gorm.DB, token *entity.PasswordResetToken) error {
+	return db.Create(token).Error
+}
+
+// FindValidByHash hanya mengembalikan token yang belum dipakai dan belum kedaluwarsa
+func (r *passwordResetRepositoryImpl) FindValidByHash(tokenHash string) (*entity.PasswordResetToken, error) {
+	var token entity.PasswordResetToken
+	err := r.db.Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, time.Now()).First(&token).Error
+	return &token, err
+}
+
+// MarkUsed mengembalikan false jika token sudah lebih dulu dipakai oleh request lain
+func (r *passwordResetRepositoryImpl) MarkUsed(db *gorm.DB, id uint) (bool, error) {
+	result := db.Model(&entity.PasswordResetToken{}).Where("id = ? AND used_at IS NULL", id).Update("used_at", time.Now())
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *passwordResetRepositoryImpl) InvalidateByUserID(db *gorm.DB, userID uint) error {
+	return db.Model(&entity.PasswordResetToken{}).Where("user_id = ? AND used_at IS NULL", userID).Update("used_at", time.Now()).Error
+}
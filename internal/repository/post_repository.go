@@ -1,18 +1,58 @@
 package repository
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// postSearchConfig memetakan kode bahasa ke konfigurasi text search PostgreSQL dan kolom tsvector-nya
+type postSearchConfig struct {
+	config string
+	column string
+}
+
+var postSearchConfigs = map[string]postSearchConfig{
+	"en": {config: "english", column: "posts.search_vector_en"},
+	"id": {config: "indonesian", column: "posts.search_vector_id"},
+}
+
+// postHeadlineOptions membatasi cuplikan ts_headline ke beberapa fragmen pendek dengan kata yang cocok ditandai <mark>
+const postHeadlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2"
+
+// PostSortFields adalah field yang boleh dipakai di parameter sort daftar post
+var PostSortFields = map[string]SortField{
+	"published_at": {Column: "posts.published_at", NullsLast: true},
+	"created_at":   {Column: "posts.created_at"},
+	"updated_at":   {Column: "posts.updated_at"},
+	"title":        {Column: "posts.title"},
+}
+
+// PostSearchResult adalah post hasil pencarian beserta skor ts_rank dan cuplikan konten yang di-highlight
+type PostSearchResult struct {
+	Post     entity.Post
+	Rank     float64
+	Headline string
+}
 
 type PostRepository interface {
-	Create(post *entity.Post) error
+	Create(db *gorm.DB, post *entity.Post) error
 	FindByID(id uint) (*entity.Post, error)
 	FindBySlug(slug string) (*entity.Post, error)
-	FindAll(offset, limit int) ([]entity.Post, error)
-	Update(post *entity.Post) error
+	FindPublishedByID(id uint) (*entity.Post, error)
+	FindPublishedBySlug(slug string) (*entity.Post, error)
+	FindAll(spec *QuerySpec) ([]entity.Post, int64, error)
+	FindPublishedByAuthorID(authorID uint, offset, limit int) ([]entity.Post, int64, error)
+	FindByAuthorAndStatuses(authorID uint, statuses []entity.PostStatus, offset, limit int) ([]entity.Post, int64, error)
+	Search(language, keyword string, offset, limit int) ([]PostSearchResult, int64, error)
+	Update(db *gorm.DB, post *entity.Post) error
+	UpdateStatus(id uint, from, to entity.PostStatus, publishedAt *time.Time) (bool, error)
+	ClaimDueScheduled(db *gorm.DB, now time.Time, limit int) ([]entity.Post, error)
 	Delete(id uint) error
+	ReassignAuthor(db *gorm.DB, fromID, toID uint) (int64, error)
 }
 
 type PostRepositoryImpl struct {
@@ -23,8 +63,8 @@ func NewPostRepository(db *gorm.DB) PostRepository {
 	return &PostRepositoryImpl{db: db}
 }
 
-func (r *PostRepositoryImpl) Create(post *entity.Post) error {
-	return r.db.Create(post).Error
+func (r *PostRepositoryImpl) Create(db *gorm.DB, post *entity.Post) error {
+	return db.Create(post).Error
 }
 
 func (r *PostRepositoryImpl) FindByID(id uint) (*entity.Post, error) {
@@ -39,22 +79,199 @@ func (r *PostRepositoryImpl) FindBySlug(slug string) (*entity.Post, error) {
 	return &post, err
 }
 
-func (r *PostRepositoryImpl) FindAll(offset, limit int) ([]entity.Post, error) {
+// PublishedPosts membatasi query pada post yang terlihat publik. Post terjadwal yang waktunya sudah lewat ikut
+// ditampilkan agar tidak tertahan jika statusnya belum sempat diubah menjadi published.
+func PublishedPosts(db *gorm.DB) *gorm.DB {
+	return db.Where("(posts.status = ? OR (posts.status = ? AND posts.published_at <= NOW()))", entity.PostStatusPublished, entity.PostStatusScheduled)
+}
+
+// PostsInCategory memfilter post yang memiliki kategori dengan slug tersebut
+func PostsInCategory(slug string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("EXISTS (SELECT 1 FROM post_categories JOIN categories ON categories.id = post_categories.category_id WHERE post_categories.post_id = posts.id AND categories.slug = ?)", slug)
+	}
+}
+
+// PostsByAuthor memfilter post berdasarkan username penulis
+func PostsByAuthor(username string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("posts.author_id IN (SELECT id FROM users WHERE username = ? AND deleted_at IS NULL)", username)
+	}
+}
+
+func PostsWithStatus(status entity.PostStatus) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("posts.status = ?", status)
+	}
+}
+
+// PostsPublishedBetween memfilter published_at dalam rentang [from, to); batas yang nil diabaikan
+func PostsPublishedBetween(from, to *time.Time) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if from != nil {
+			db = db.Where("posts.published_at >= ?", *from)
+		}
+		if to != nil {
+			db = db.Where("posts.published_at < ?", *to)
+		}
+		return db
+	}
+}
+
+func (r *PostRepositoryImpl) FindPublishedByID(id uint) (*entity.Post, error) {
+	var post entity.Post
+	err := r.db.Scopes(PublishedPosts).Preload("Author").Preload("Categories").First(&post, id).Error
+	return &post, err
+}
+
+func (r *PostRepositoryImpl) FindPublishedBySlug(slug string) (*entity.Post, error) {
+	var post entity.Post
+	err := r.db.Scopes(PublishedPosts).Preload("Author").Preload("Categories").Where("slug = ?", slug).First(&post).Error
+	return &post, err
+}
+
+// FindAll mengembalikan satu halaman post sesuai spec beserta total untuk paginasi. Visibilitas tidak dibatasi di sini;
+// daftar publik harus menambahkan scope PublishedPosts.
+func (r *PostRepositoryImpl) FindAll(spec *QuerySpec) ([]entity.Post, int64, error) {
 	var posts []entity.Post
-	// Tambahkan Preload("Categories")
-	err := r.db.Offset(offset).Limit(limit).Order("published_at desc").Preload("Author").Preload("Categories").Find(&posts).Error
-	return posts, err
+	total, err := FindPage(r.db, spec, &posts, "Author", "Categories")
+	return posts, total, err
 }
 
-func (r *PostRepositoryImpl) Update(post *entity.Post) error {
-	// Untuk many-to-many, kita perlu menggunakan Asociation() atau Select() pada update
-	// GORM otomatis akan menangani update relasi many2many jika post.Categories dimodifikasi
-	// dan kemudian Save dipanggil. Jika ada perubahan pada asosiasi, GORM akan mengurus
-	// penghapusan dan penambahan entri di tabel pivot.
-	return r.db.Save(post).Error
+// FindPublishedByAuthorID hanya mengembalikan post yang sudah terbit, beserta total untuk paginasi
+func (r *PostRepositoryImpl) FindPublishedByAuthorID(authorID uint, offset, limit int) ([]entity.Post, int64, error) {
+	spec := &QuerySpec{Offset: offset, Limit: limit}
+	spec.Scope(PublishedPosts).Where("posts.author_id = ?", authorID).OrderBy(SortField{Column: "posts.published_at", Desc: true})
+
+	var posts []entity.Post
+	total, err := FindPage(r.db, spec, &posts, "Categories")
+	return posts, total, err
+}
+
+// FindByAuthorAndStatuses dipakai untuk daftar draft milik author sendiri, yang terakhir diubah lebih dulu
+func (r *PostRepositoryImpl) FindByAuthorAndStatuses(authorID uint, statuses []entity.PostStatus, offset, limit int) ([]entity.Post, int64, error) {
+	spec := &QuerySpec{Offset: offset, Limit: limit}
+	spec.Where("posts.author_id = ? AND posts.status IN ?", authorID, statuses).OrderBy(SortField{Column: "posts.updated_at", Desc: true})
+
+	var posts []entity.Post
+	total, err := FindPage(r.db, spec, &posts, "Author", "Categories")
+	return posts, total, err
+}
+
+// Search mencari post terbit dengan websearch_to_tsquery sehingga sintaks seperti "frasa", OR, dan -kata bisa dipakai.
+// Hasil diurutkan berdasarkan ts_rank; ts_headline hanya dihitung untuk halaman yang diminta karena mahal.
+func (r *PostRepositoryImpl) Search(language, keyword string, offset, limit int) ([]PostSearchResult, int64, error) {
+	search, ok := postSearchConfigs[language]
+	if !ok {
+		return nil, 0, fmt.Errorf("bahasa pencarian %q tidak didukung", language)
+	}
+
+	query := r.db.Model(&entity.Post{}).
+		Scopes(PublishedPosts).
+		Joins("CROSS JOIN websearch_to_tsquery(?::regconfig, ?) AS search_query", search.config, keyword).
+		Where(search.column + " @@ search_query").
+		Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var hits []struct {
+		ID       uint
+		Rank     float64
+		Headline string
+	}
+	err := query.
+		Select("posts.id, ts_rank("+search.column+", search_query) AS rank, ts_headline(?::regconfig, posts.content, search_query, ?) AS headline", search.config, postHeadlineOptions).
+		Order("rank DESC, posts.published_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Scan(&hits).Error
+	if err != nil || len(hits) == 0 {
+		return nil, total, err
+	}
+
+	ids := make([]uint, len(hits))
+	for i := range hits {
+		ids[i] = hits[i].ID
+	}
+
+	var posts []entity.Post
+	if err := r.db.Preload("Author").Preload("Categories").Where("id IN ?", ids).Find(&posts).Error; err != nil {
+		return nil, 0, err
+	}
+	postsByID := make(map[uint]entity.Post, len(posts))
+	for _, post := range posts {
+		postsByID[post.ID] = post
+	}
+
+	results := make([]PostSearchResult, 0, len(hits))
+	for _, hit := range hits {
+		post, ok := postsByID[hit.ID]
+		if !ok {
+			continue
+		}
+		results = append(results, PostSearchResult{Post: post, Rank: hit.Rank, Headline: hit.Headline})
+	}
+	return results, total, nil
+}
+
+// Update menyimpan kolom post lalu mengganti isi tabel pivot post_categories. Save saja hanya menambah
+// kategori baru tanpa menghapus yang lama, dan Author dilewati agar data user tidak ikut tertulis.
+// Status dan published_at hanya diubah lewat UpdateStatus/ClaimDueScheduled; menulis ulang nilai yang dibaca
+// sebelumnya bisa mengembalikan post yang baru diterbitkan scheduler ke scheduled dan menerbitkannya dua kali.
+func (r *PostRepositoryImpl) Update(db *gorm.DB, post *entity.Post) error {
+	if err := db.Omit("Author", "Categories", "Status", "PublishedAt").Save(post).Error; err != nil {
+		return err
+	}
+	return db.Model(post).Association("Categories").Replace(post.Categories)
+}
+
+// UpdateStatus hanya mengubah status jika post masih berstatus from; false berarti status sudah diubah di tempat lain
+func (r *PostRepositoryImpl) UpdateStatus(id uint, from, to entity.PostStatus, publishedAt *time.Time) (bool, error) {
+	result := r.db.Model(&entity.Post{}).Where("id = ? AND status = ?", id, from).Updates(map[string]interface{}{
+		"status":       to,
+		"published_at": publishedAt,
+		"updated_at":   time.Now(),
+	})
+	return result.RowsAffected > 0, result.Error
+}
+
+// ClaimDueScheduled mengubah post terjadwal yang waktunya sudah lewat menjadi published dan mengembalikannya.
+// Harus dipanggil di dalam transaksi: FOR UPDATE SKIP LOCKED memastikan replika lain melewati baris yang sedang
+// diklaim, sehingga setiap post hanya diterbitkan satu kali.
+func (r *PostRepositoryImpl) ClaimDueScheduled(db *gorm.DB, now time.Time, limit int) ([]entity.Post, error) {
+	var posts []entity.Post
+	err := db.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND published_at <= ?", entity.PostStatusScheduled, now).
+		Order("published_at").
+		Limit(limit).
+		Find(&posts).Error
+	if err != nil || len(posts) == 0 {
+		return nil, err
+	}
+
+	ids := make([]uint, len(posts))
+	for i := range posts {
+		ids[i] = posts[i].ID
+		posts[i].Status = entity.PostStatusPublished
+	}
+
+	err = db.Model(&entity.Post{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+		"status":     entity.PostStatusPublished,
+		"updated_at": now,
+	}).Error
+	return posts, err
 }
 
 // Delete menghapus postingan dari database (soft delete)
 func (r *PostRepositoryImpl) Delete(id uint) error {
 	return r.db.Delete(&entity.Post{}, id).Error
-}
\ No newline at end of file
+}
+
+// ReassignAuthor memindahkan semua post milik fromID ke toID, dipakai saat akun dihapus
+func (r *PostRepositoryImpl) ReassignAuthor(db *gorm.DB, fromID, toID uint) (int64, error) {
+	result := db.Model(&entity.Post{}).Where("author_id = ?", fromID).Update("author_id", toID)
+	return result.RowsAffected, result.Error
+}
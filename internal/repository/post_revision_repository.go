@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostRevisionRepository interface {
+	Create(db *gorm.DB, revision *entity.PostRevision) error
+	CountByPostID(db *gorm.DB, postID uint) (int64, error)
+	FindByPostID(postID uint) ([]entity.PostRevision, error)
+	FindByRevision(postID uint, revision int) (*entity.PostRevision, error)
+}
+
+type postRevisionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewPostRevisionRepository(db *gorm.DB) PostRevisionRepository {
+	return &postRevisionRepositoryImpl{db: db}
+}
+
+// Create memberi nomor revisi berikutnya. Harus dipanggil di dalam transaksi: baris post dikunci lebih dulu
+// supaya dua perubahan bersamaan tidak mendapat nomor revisi yang sama.
+func (r *postRevisionRepositoryImpl) Create(db *gorm.DB, revision *entity.PostRevision) error {
+	var postID uint
+	if err := db.Model(&entity.Post{}).Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", revision.PostID).Scan(&postID).Error; err != nil {
+		return err
+	}
+
+	if err := db.Model(&entity.PostRevision{}).Select("COALESCE(MAX(revision), 0) + 1").Where("post_id = ?", revision.PostID).Scan(&revision.Revision).Error; err != nil {
+		return err
+	}
+	return db.Create(revision).Error
+}
+
+func (r *postRevisionRepositoryImpl) CountByPostID(db *gorm.DB, postID uint) (int64, error) {
+	var total int64
+	err := db.Model(&entity.PostRevision{}).Where("post_id = ?", postID).Count(&total).Error
+	return total, err
+}
+
+// FindByPostID mengembalikan revisi terbaru lebih dulu
+func (r *postRevisionRepositoryImpl) FindByPostID(postID uint) ([]entity.PostRevision, error) {
+	var revisions []entity.PostRevision
+	err := r.db.Preload("Editor").Where("post_id = ?", postID).Order("revision desc").Find(&revisions).Error
+	return revisions, err
+}
+
+func (r *postRevisionRepositoryImpl) FindByRevision(postID uint, revision int) (*entity.PostRevision, error) {
+	var postRevision entity.PostRevision
+	err := r.db.Preload("Editor").Where("post_id = ? AND revision = ?", postID, revision).First(&postRevision).Error
+	return &postRevision, err
+}
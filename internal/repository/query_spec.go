@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// SortField adalah satu kolom pengurutan. Column selalu berasal dari whitelist repository, bukan langsung dari input user.
+// NullsLast dipakai untuk kolom nullable agar baris tanpa nilai tetap di akhir saat descending; tidak dipasang
+// default karena index "kolom DESC" tidak bisa dipakai untuk ORDER BY "kolom DESC NULLS LAST".
+type SortField struct {
+	Column    string
+	Desc      bool
+	NullsLast bool
+}
+
+// QuerySpec mengumpulkan filter, urutan, dan paginasi untuk query daftar sehingga count dan find memakai kondisi yang sama
+type QuerySpec struct {
+	scopes []func(*gorm.DB) *gorm.DB
+	Sort   []SortField
+	Offset int
+	Limit  int
+}
+
+func NewQuerySpec(page, size int) *QuerySpec {
+	return &QuerySpec{Offset: (page - 1) * size, Limit: size}
+}
+
+func (s *QuerySpec) Where(query string, args ...interface{}) *QuerySpec {
+	return s.Scope(func(db *gorm.DB) *gorm.DB {
+		return db.Where(query, args...)
+	})
+}
+
+// Scope menambah filter yang sudah disiapkan repository, misalnya PostsInCategory
+func (s *QuerySpec) Scope(scope func(*gorm.DB) *gorm.DB) *QuerySpec {
+	s.scopes = append(s.scopes, scope)
+	return s
+}
+
+func (s *QuerySpec) OrderBy(fields ...SortField) *QuerySpec {
+	s.Sort = append(s.Sort, fields...)
+	return s
+}
+
+// Filter hanya menerapkan filter, tanpa urutan dan paginasi, sehingga bisa dipakai untuk Count
+func (s *QuerySpec) Filter(db *gorm.DB) *gorm.DB {
+	return db.Scopes(s.scopes...)
+}
+
+func (s *QuerySpec) Paginate(db *gorm.DB) *gorm.DB {
+	for _, field := range s.Sort {
+		switch {
+		case field.Desc && field.NullsLast:
+			db = db.Order(field.Column + " DESC NULLS LAST")
+		case field.Desc:
+			db = db.Order(field.Column + " DESC")
+		default:
+			// NULL sudah berada di akhir pada urutan ascending PostgreSQL
+			db = db.Order(field.Column)
+		}
+	}
+	if s.Limit > 0 {
+		db = db.Offset(s.Offset).Limit(s.Limit)
+	}
+	return db
+}
+
+// FindPage menghitung total baris yang cocok lalu mengambil satu halaman ke dest. Preload diterapkan setelah count.
+func FindPage[T any](db *gorm.DB, spec *QuerySpec, dest *[]T, preloads ...string) (int64, error) {
+	query := db.Model(new(T)).Scopes(spec.Filter).Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return 0, err
+	}
+
+	find := query.Scopes(spec.Paginate)
+	for _, preload := range preloads {
+		find = find.Preload(preload)
+	}
+	return total, find.Find(dest).Error
+}
+
+// ParseSort membaca parameter sort seperti "-published_at,title"; awalan "-" berarti descending.
+// allowed memetakan nama field di API ke kolom database, field di luar daftar itu ditolak.
+func ParseSort(value string, allowed map[string]SortField) ([]SortField, error) {
+	var fields []SortField
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		desc := strings.HasPrefix(part, "-")
+		name := strings.TrimPrefix(part, "-")
+		field, ok := allowed[name]
+		if !ok {
+			return nil, fmt.Errorf("tidak bisa mengurutkan berdasarkan %q", name)
+		}
+		field.Desc = desc
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
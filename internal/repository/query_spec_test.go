@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// dryRunDB menghasilkan SQL dengan dialek PostgreSQL tanpa membuka koneksi ke database
+func dryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		t.Fatalf("gorm.Open() error = %v", err)
+	}
+	return db
+}
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []SortField
+		wantErr bool
+	}{
+		{name: "kosong", value: "", want: nil},
+		{name: "hanya koma dan spasi", value: " , ,", want: nil},
+		{
+			name:  "ascending",
+			value: "title",
+			want:  []SortField{{Column: "posts.title"}},
+		},
+		{
+			name:  "descending mempertahankan NullsLast dari whitelist",
+			value: "-published_at",
+			want:  []SortField{{Column: "posts.published_at", Desc: true, NullsLast: true}},
+		},
+		{
+			name:  "beberapa field dengan spasi",
+			value: " -created_at , title ",
+			want:  []SortField{{Column: "posts.created_at", Desc: true}, {Column: "posts.title"}},
+		},
+		{name: "field di luar whitelist", value: "password_hash", wantErr: true},
+		{name: "nama kolom database tidak diterima langsung", value: "-posts.title", wantErr: true},
+		{name: "satu field salah menolak seluruh parameter", value: "title,-id", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSort(tt.value, PostSortFields)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSort() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSort() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSortDoesNotModifyAllowed(t *testing.T) {
+	if _, err := ParseSort("-title", PostSortFields); err != nil {
+		t.Fatalf("ParseSort() error = %v", err)
+	}
+	if PostSortFields["title"].Desc {
+		t.Fatal("ParseSort() mengubah isi PostSortFields")
+	}
+}
+
+func TestQuerySpecPaginate(t *testing.T) {
+	tests := []struct {
+		name string
+		spec *QuerySpec
+		want string
+	}{
+		{
+			name: "tanpa urutan",
+			spec: NewQuerySpec(1, 10),
+			want: `SELECT * FROM "posts" LIMIT 10`,
+		},
+		{
+			name: "halaman kedua",
+			spec: NewQuerySpec(2, 10).OrderBy(SortField{Column: "posts.title"}),
+			want: `SELECT * FROM "posts" ORDER BY posts.title LIMIT 10 OFFSET 10`,
+		},
+		{
+			name: "descending dengan dan tanpa NullsLast",
+			spec: NewQuerySpec(1, 5).OrderBy(
+				SortField{Column: "posts.published_at", Desc: true, NullsLast: true},
+				SortField{Column: "posts.created_at", Desc: true},
+			),
+			want: `SELECT * FROM "posts" ORDER BY posts.published_at DESC NULLS LAST,posts.created_at DESC LIMIT 5`,
+		},
+		{
+			name: "NullsLast diabaikan pada ascending",
+			spec: NewQuerySpec(1, 5).OrderBy(SortField{Column: "posts.published_at", NullsLast: true}),
+			want: `SELECT * FROM "posts" ORDER BY posts.published_at LIMIT 5`,
+		},
+		{
+			name: "limit nol tanpa paginasi",
+			spec: NewQuerySpec(1, 0).OrderBy(SortField{Column: "posts.id"}),
+			want: `SELECT * FROM "posts" ORDER BY posts.id`,
+		},
+		{
+			name: "filter dan scope",
+			spec: NewQuerySpec(1, 10).Where("posts.author_id = ?", 7).Scope(PostsWithStatus(entity.PostStatusDraft)),
+			want: `SELECT * FROM "posts" WHERE posts.author_id = 7 AND posts.status = 'draft' LIMIT 10`,
+		},
+	}
+
+	db := dryRunDB(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var posts []entity.Post
+			stmt := db.Model(&entity.Post{}).Scopes(tt.spec.Filter, tt.spec.Paginate).Find(&posts).Statement
+			if got := db.Dialector.Explain(stmt.SQL.String(), stmt.Vars...); got != tt.want {
+				t.Errorf("SQL =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
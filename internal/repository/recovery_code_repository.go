@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"gorm.io/gorm"
+)
+
+type RecoveryCodeRepository interface {
+	Replace(db *gorm.DB, userID uint, codeHashes []string) error
+	Consume(db *gorm.DB, userID uint, codeHash string) (bool, error)
+	DeleteByUserID(db *gorm.DB, userID uint) error
+}
+
+type recoveryCodeRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRecoveryCodeRepository(db *gorm.DB) RecoveryCodeRepository {
+	return &recoveryCodeRepositoryImpl{db: db}
+}
+
+// Replace menghapus semua kode lama user lalu menyimpan kode baru, sehingga hanya satu set kode yang berlaku
+func (r *recoveryCodeRepositoryImpl) Replace(db *gorm.DB, userID uint, codeHashes []string) error {
+	if err := r.DeleteByUserID(db, userID); err != nil {
+		return err
+	}
+
+	codes := make([]entity.RecoveryCode, len(codeHashes))
+	for i, hash := range codeHashes {
+		codes[i] = entity.RecoveryCode{UserID: userID, CodeHash: hash}
+	}
+	return db.Create(&codes).Error
+}
+
+// Consume menandai kode sebagai terpakai secara atomik; false berarti kode tidak ada atau sudah pernah dipakai
+func (r *recoveryCodeRepositoryImpl) Consume(db *gorm.DB, userID uint, codeHash string) (bool, error) {
+	result := db.Model(&entity.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *recoveryCodeRepositoryImpl) DeleteByUserID(db *gorm.DB, userID uint) error {
+	return db.Where("user_id = ?", userID).Delete(&entity.RecoveryCode{}).Error
+}
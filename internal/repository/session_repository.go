@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"github.com/redis/go-redis/v9"
+)
+
+var ErrSessionNotFound = errors.New("sesi tidak ditemukan")
+
+const (
+	sessionKeyPrefix      = "session:"
+	userSessionsKeyPrefix = "user_sessions:"
+)
+
+// touchSessionScript hanya memperbarui sesi yang masih ada agar sesi yang sudah dicabut tidak hidup kembali
+var touchSessionScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+redis.call('HSET', KEYS[1], 'last_active_at', ARGV[1], 'ip', ARGV[2])
+return 1
+`)
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *entity.Session, ttl time.Duration) error
+	Find(ctx context.Context, sessionID string) (*entity.Session, error)
+	FindByUserID(ctx context.Context, userID uint) ([]entity.Session, error)
+	Touch(ctx context.Context, sessionID, ip string, at time.Time) error
+	Extend(ctx context.Context, userID uint, sessionID string, ttl time.Duration) error
+	Delete(ctx context.Context, userID uint, sessionID string) error
+	DeleteByUserID(ctx context.Context, userID uint) ([]string, error)
+}
+
+type sessionRepositoryImpl struct {
+	redis *redis.Client
+}
+
+func NewSessionRepository(redis *redis.Client) SessionRepository {
+	return &sessionRepositoryImpl{redis: redis}
+}
+
+func (r *sessionRepositoryImpl) Create(ctx context.Context, session *entity.Session, ttl time.Duration) error {
+	pipe := r.redis.TxPipeline()
+	pipe.HSet(ctx, sessionKeyPrefix+session.ID, map[string]interface{}{
+		"user_id":        session.UserID,
+		"user_agent":     session.UserAgent,
+		"ip":             session.IP,
+		"created_at":     session.CreatedAt.Unix(),
+		"last_active_at": session.LastActiveAt.Unix(),
+	})
+	pipe.Expire(ctx, sessionKeyPrefix+session.ID, ttl)
+	pipe.SAdd(ctx, userSessionsKey(session.UserID), session.ID)
+	pipe.Expire(ctx, userSessionsKey(session.UserID), ttl)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (r *sessionRepositoryImpl) Find(ctx context.Context, sessionID string) (*entity.Session, error) {
+	values, err := r.redis.HGetAll(ctx, sessionKeyPrefix+sessionID).Result()
+	if err != nil {
+		return nil, err
+	}
+	return parseSession(sessionID, values)
+}
+
+// FindByUserID mengurutkan sesi dari yang terakhir aktif dan membersihkan ID sesi yang sudah kedaluwarsa dari indeks user
+func (r *sessionRepositoryImpl) FindByUserID(ctx context.Context, userID uint) ([]entity.Session, error) {
+	ids, err := r.redis.SMembers(ctx, userSessionsKey(userID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := r.redis.Pipeline()
+	cmds := make([]*redis.MapStringStringCmd, len(ids))
+	for i, id := range ids {
+		cmds[i] = pipe.HGetAll(ctx, sessionKeyPrefix+id)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	sessions := make([]entity.Session, 0, len(ids))
+	var stale []interface{}
+	for i, cmd := range cmds {
+		session, err := parseSession(ids[i], cmd.Val())
+		if errors.Is(err, ErrSessionNotFound) {
+			stale = append(stale, ids[i])
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, *session)
+	}
+
+	if len(stale) > 0 {
+		if err := r.redis.SRem(ctx, userSessionsKey(userID), stale...).Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastActiveAt.After(sessions[j].LastActiveAt)
+	})
+	return sessions, nil
+}
+
+func (r *sessionRepositoryImpl) Touch(ctx context.Context, sessionID, ip string, at time.Time) error {
+	touched, err := touchSessionScript.Run(ctx, r.redis, []string{sessionKeyPrefix + sessionID}, at.Unix(), ip).Int()
+	if err != nil {
+		return err
+	}
+	if touched == 0 {
+		return ErrSessionNotFound
+	}
+	return nil
+}
+
+// Extend memperpanjang umur sesi (dan indeks sesi user) setiap kali refresh token dirotasi
+func (r *sessionRepositoryImpl) Extend(ctx context.Context, userID uint, sessionID string, ttl time.Duration) error {
+	pipe := r.redis.TxPipeline()
+	extended := pipe.Expire(ctx, sessionKeyPrefix+sessionID, ttl)
+	pipe.Expire(ctx, userSessionsKey(userID), ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+	if !extended.Val() {
+		return ErrSessionNotFound
+	}
+	return nil
+}
+
+func (r *sessionRepositoryImpl) Delete(ctx context.Context, userID uint, sessionID string) error {
+	pipe := r.redis.TxPipeline()
+	pipe.Del(ctx, sessionKeyPrefix+sessionID)
+	pipe.SRem(ctx, userSessionsKey(userID), sessionID)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (r *sessionRepositoryImpl) DeleteByUserID(ctx context.Context, userID uint) ([]string, error) {
+	ids, err := r.redis.SMembers(ctx, userSessionsKey(userID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []string{userSessionsKey(userID)}
+	for _, id := range ids {
+		keys = append(keys, sessionKeyPrefix+id)
+	}
+	return ids, r.redis.Del(ctx, keys...).Err()
+}
+
+func parseSession(sessionID string, values map[string]string) (*entity.Session, error) {
+	if len(values) == 0 {
+		return nil, ErrSessionNotFound
+	}
+
+	userID, err := strconv.ParseUint(values["user_id"], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	createdAt, _ := strconv.ParseInt(values["created_at"], 10, 64)
+	lastActiveAt, _ := strconv.ParseInt(values["last_active_at"], 10, 64)
+
+	return &entity.Session{
+		ID:           sessionID,
+		UserID:       uint(userID),
+		UserAgent:    values["user_agent"],
+		IP:           values["ip"],
+		CreatedAt:    time.Unix(createdAt, 0),
+		LastActiveAt: time.Unix(lastActiveAt, 0),
+	}, nil
+}
+
+func userSessionsKey(userID uint) string {
+	return fmt.Sprintf("%s%d", userSessionsKeyPrefix, userID)
+}
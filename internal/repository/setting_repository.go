@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SettingRepository interface {
+	Get(key string) (string, bool, error)
+	Set(key, value string) error
+}
+
+type settingRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewSettingRepository(db *gorm.DB) SettingRepository {
+	return &settingRepositoryImpl{db: db}
+}
+
+// Get mengembalikan false jika pengaturan belum pernah disimpan, sehingga pemanggil dapat memakai nilai default
+func (r *settingRepositoryImpl) Get(key string) (string, bool, error) {
+	var setting entity.Setting
+	err := r.db.Where("key = ?", key).First(&setting).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return setting.Value, true, nil
+}
+
+func (r *settingRepositoryImpl) Set(key, value string) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+	}).Create(&entity.Setting{Key: key, Value: value}).Error
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const throttleKeyPrefix = "throttle:"
+
+// ThrottleRepository membatasi sebuah aksi agar hanya boleh dilakukan sekali per interval
+type ThrottleRepository interface {
+	Allow(ctx context.Context, key string, interval time.Duration) (bool, error)
+}
+
+type throttleRepositoryImpl struct {
+	redis *redis.Client
+}
+
+func NewThrottleRepository(redis *redis.Client) ThrottleRepository {
+	return &throttleRepositoryImpl{redis: redis}
+}
+
+func (r *throttleRepositoryImpl) Allow(ctx context.Context, key string, interval time.Duration) (bool, error) {
+	return r.redis.SetNX(ctx, throttleKeyPrefix+key, 1, interval).Result()
+}
@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	ErrRefreshTokenNotFound = errors.New("refresh token tidak ditemukan")
+	ErrRefreshTokenReused   = errors.New("refresh token sudah pernah digunakan")
+)
+
+const (
+	refreshTokenKeyPrefix     = "refresh_token:"
+	refreshTokenUsedKeyPrefix = "refresh_token_used:"
+	refreshFamilyKeyPrefix    = "refresh_family:"
+	refreshUserFamiliesPrefix = "refresh_user_families:"
+	revokedAccessTokenPrefix  = "revoked_access_token:"
+	tokenVersionKeyPrefix     = "token_version:"
+	revokedSessionKeyPrefix   = "revoked_session:"
+)
+
+// consumeRefreshTokenScript mengambil dan menghapus token secara atomik, lalu menandainya sebagai "used".
+// Penanda menyimpan data token (user dan family) sehingga sesi milik user dapat dicabut saat token dipakai ulang.
+// Jika token sudah tidak ada tetapi penanda "used" masih ada, berarti token hasil rotasi dipakai ulang.
+var consumeRefreshTokenScript = redis.NewScript(`
+local data = redis.call('GET', KEYS[1])
+if data then
+	redis.call('DEL', KEYS[1])
+	redis.call('SET', KEYS[2], data, 'EX', ARGV[1])
+	return {'ok', data}
+end
+local used = redis.call('GET', KEYS[2])
+if used then
+	return {'reused', used}
+end
+return {'missing', ''}
+`)
+
+type TokenRepository interface {
+	StoreRefreshToken(ctx context.Context, token string, data *entity.RefreshToken, ttl time.Duration) error
+	ConsumeRefreshToken(ctx context.Context, token string, ttl time.Duration) (*entity.RefreshToken, error)
+	RevokeRefreshFamily(ctx context.Context, familyID string) error
+	RevokeAllRefreshFamilies(ctx context.Context, userID uint) error
+	RevokeAccessToken(ctx context.Context, tokenID string, ttl time.Duration) error
+	GetTokenVersion(ctx context.Context, userID uint) (int64, error)
+	IncrementTokenVersion(ctx context.Context, userID uint) (int64, error)
+	RevokeSession(ctx context.Context, sessionID string, ttl time.Duration) error
+	IsAccessTokenRevoked(ctx context.Context, tokenID, sessionID string, userID uint, version int64) (bool, error)
+}
+
+type tokenRepositoryImpl struct {
+	redis *redis.Client
+}
+
+func NewTokenRepository(redis *redis.Client) TokenRepository {
+	return &tokenRepositoryImpl{redis: redis}
+}
+
+func (r *tokenRepositoryImpl) StoreRefreshToken(ctx context.Context, token string, data *entity.RefreshToken, ttl time.Duration) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	hash := utils.HashToken(token)
+	pipe := r.redis.TxPipeline()
+	pipe.Set(ctx, refreshTokenKeyPrefix+hash, payload, ttl)
+	pipe.Set(ctx, refreshFamilyKeyPrefix+data.FamilyID, hash, ttl)
+	pipe.SAdd(ctx, userFamiliesKey(data.UserID), data.FamilyID)
+	pipe.Expire(ctx, userFamiliesKey(data.UserID), ttl)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// ConsumeRefreshToken mengembalikan data token dan menghapusnya dari Redis.
+// Pada penggunaan ulang, UserID dan FamilyID tetap dikembalikan bersama ErrRefreshTokenReused agar sesi dapat dicabut.
+func (r *tokenRepositoryImpl) ConsumeRefreshToken(ctx context.Context, token string, ttl time.Duration) (*entity.RefreshToken, error) {
+	hash := utils.HashToken(token)
+	keys := []string{refreshTokenKeyPrefix + hash, refreshTokenUsedKeyPrefix + hash}
+
+	result, err := consumeRefreshTokenScript.Run(ctx, r.redis, keys, int64(ttl.Seconds())).StringSlice()
+	if err != nil {
+		return nil, err
+	}
+
+	switch result[0] {
+	case "ok":
+		data := new(entity.RefreshToken)
+		if err := json.Unmarshal([]byte(result[1]), data); err != nil {
+			return nil, err
+		}
+
+		current, err := r.redis.Get(ctx, refreshFamilyKeyPrefix+data.FamilyID).Result()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return nil, err
+		}
+		if current != hash {
+			return nil, ErrRefreshTokenNotFound
+		}
+		return data, nil
+	case "reused":
+		data := new(entity.RefreshToken)
+		if err := json.Unmarshal([]byte(result[1]), data); err != nil {
+			// penanda lama hanya berisi family id; user id tidak diketahui sampai penanda tersebut kedaluwarsa
+			data = &entity.RefreshToken{FamilyID: result[1]}
+		}
+		return data, ErrRefreshTokenReused
+	default:
+		return nil, ErrRefreshTokenNotFound
+	}
+}
+
+func (r *tokenRepositoryImpl) RevokeRefreshFamily(ctx context.Context, familyID string) error {
+	familyKey := refreshFamilyKeyPrefix + familyID
+
+	hash, err := r.redis.Get(ctx, familyKey).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return err
+	}
+
+	keys := []string{familyKey}
+	if hash != "" {
+		keys = append(keys, refreshTokenKeyPrefix+hash)
+	}
+	return r.redis.Del(ctx, keys...).Err()
+}
+
+func (r *tokenRepositoryImpl) RevokeAllRefreshFamilies(ctx context.Context, userID uint) error {
+	families, err := r.redis.SMembers(ctx, userFamiliesKey(userID)).Result()
+	if err != nil {
+		return err
+	}
+
+	for _, familyID := range families {
+		if err := r.RevokeRefreshFamily(ctx, familyID); err != nil {
+			return err
+		}
+	}
+	return r.redis.Del(ctx, userFamiliesKey(userID)).Err()
+}
+
+// RevokeAccessToken menyimpan jti yang dicabut sampai token tersebut kedaluwarsa dengan sendirinya
+func (r *tokenRepositoryImpl) RevokeAccessToken(ctx context.Context, tokenID string, ttl time.Duration) error {
+	if ttl <= 0 {
+		return nil
+	}
+	return r.redis.Set(ctx, revokedAccessTokenPrefix+tokenID, 1, ttl).Err()
+}
+
+// RevokeSession menandai semua access token milik sesi sebagai dicabut sampai token terakhirnya kedaluwarsa
+func (r *tokenRepositoryImpl) RevokeSession(ctx context.Context, sessionID string, ttl time.Duration) error {
+	if ttl <= 0 {
+		return nil
+	}
+	return r.redis.Set(ctx, revokedSessionKeyPrefix+sessionID, 1, ttl).Err()
+}
+
+func (r *tokenRepositoryImpl) GetTokenVersion(ctx context.Context, userID uint) (int64, error) {
+	version, err := r.redis.Get(ctx, tokenVersionKey(userID)).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return version, err
+}
+
+func (r *tokenRepositoryImpl) IncrementTokenVersion(ctx context.Context, userID uint) (int64, error) {
+	return r.redis.Incr(ctx, tokenVersionKey(userID)).Result()
+}
+
+// IsAccessTokenRevoked dipanggil di setiap request, sehingga cukup satu MGET untuk jti, sesi, dan token version
+func (r *tokenRepositoryImpl) IsAccessTokenRevoked(ctx context.Context, tokenID, sessionID string, userID uint, version int64) (bool, error) {
+	values, err := r.redis.MGet(ctx, revokedAccessTokenPrefix+tokenID, tokenVersionKey(userID), revokedSessionKeyPrefix+sessionID).Result()
+	if err != nil {
+		return false, err
+	}
+
+	if values[0] != nil || (sessionID != "" && values[2] != nil) {
+		return true, nil
+	}
+
+	if values[1] != nil {
+		current, err := strconv.ParseInt(values[1].(string), 10, 64)
+		if err != nil {
+			return false, err
+		}
+		if version < current {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func userFamiliesKey(userID uint) string {
+	return fmt.Sprintf("%s%d", refreshUserFamiliesPrefix, userID)
+}
+
+func tokenVersionKey(userID uint) string {
+	return fmt.Sprintf("%s%d", tokenVersionKeyPrefix, userID)
+}
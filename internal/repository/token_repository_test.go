@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
+	"github.com/redis/go-redis/v9"
+)
+
+const testRefreshTTL = time.Hour
+
+func newTestTokenRepository(t *testing.T) (TokenRepository, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewTokenRepository(client), mr
+}
+
+func TestConsumeRefreshTokenReuse(t *testing.T) {
+	ctx := context.Background()
+	first := &entity.RefreshToken{UserID: 7, FamilyID: "family-1"}
+
+	tests := []struct {
+		name string
+		// setup menyiapkan state Redis dan mengembalikan token yang akan dikonsumsi
+		setup    func(t *testing.T, repo TokenRepository, mr *miniredis.Miniredis) string
+		want     *entity.RefreshToken
+		wantErr  error
+		wantNone bool
+	}{
+		{
+			name: "token aktif",
+			setup: func(t *testing.T, repo TokenRepository, mr *miniredis.Miniredis) string {
+				store(t, repo, "token-1", first)
+				return "token-1"
+			},
+			want: first,
+		},
+		{
+			name: "token tidak dikenal",
+			setup: func(t *testing.T, repo TokenRepository, mr *miniredis.Miniredis) string {
+				return "token-x"
+			},
+			wantErr:  ErrRefreshTokenNotFound,
+			wantNone: true,
+		},
+		{
+			name: "token dipakai dua kali mengembalikan user dan family",
+			setup: func(t *testing.T, repo TokenRepository, mr *miniredis.Miniredis) string {
+				store(t, repo, "token-1", first)
+				consume(t, repo, "token-1")
+				return "token-1"
+			},
+			want:    first,
+			wantErr: ErrRefreshTokenReused,
+		},
+		{
+			name: "token lama dipakai setelah rotasi",
+			setup: func(t *testing.T, repo TokenRepository, mr *miniredis.Miniredis) string {
+				store(t, repo, "token-1", first)
+				consume(t, repo, "token-1")
+				store(t, repo, "token-2", first)
+				return "token-1"
+			},
+			want:    first,
+			wantErr: ErrRefreshTokenReused,
+		},
+		{
+			name: "token yang sudah digantikan tanpa dikonsumsi",
+			setup: func(t *testing.T, repo TokenRepository, mr *miniredis.Miniredis) string {
+				store(t, repo, "token-1", first)
+				store(t, repo, "token-2", first)
+				return "token-1"
+			},
+			wantErr:  ErrRefreshTokenNotFound,
+			wantNone: true,
+		},
+		{
+			name: "penanda used kedaluwarsa",
+			setup: func(t *testing.T, repo TokenRepository, mr *miniredis.Miniredis) string {
+				store(t, repo, "token-1", first)
+				consume(t, repo, "token-1")
+				mr.FastForward(testRefreshTTL + time.Second)
+				return "token-1"
+			},
+			wantErr:  ErrRefreshTokenNotFound,
+			wantNone: true,
+		},
+		{
+			name: "penanda lama yang hanya berisi family id",
+			setup: func(t *testing.T, repo TokenRepository, mr *miniredis.Miniredis) string {
+				if err := mr.Set(refreshTokenUsedKeyPrefix+utils.HashToken("token-1"), "family-1"); err != nil {
+					t.Fatalf("Set() error = %v", err)
+				}
+				return "token-1"
+			},
+			want:    &entity.RefreshToken{FamilyID: "family-1"},
+			wantErr: ErrRefreshTokenReused,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mr := newTestTokenRepository(t)
+			token := tt.setup(t, repo, mr)
+
+			got, err := repo.ConsumeRefreshToken(ctx, token, testRefreshTTL)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ConsumeRefreshToken() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantNone {
+				if got != nil {
+					t.Errorf("ConsumeRefreshToken() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil || *got != *tt.want {
+				t.Errorf("ConsumeRefreshToken() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRevokeRefreshFamilyAfterReuse(t *testing.T) {
+	ctx := context.Background()
+	repo, _ := newTestTokenRepository(t)
+	data := &entity.RefreshToken{UserID: 7, FamilyID: "family-1"}
+
+	store(t, repo, "token-1", data)
+	consume(t, repo, "token-1")
+	store(t, repo, "token-2", data)
+
+	reused, err := repo.ConsumeRefreshToken(ctx, "token-1", testRefreshTTL)
+	if !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("ConsumeRefreshToken() error = %v, want ErrRefreshTokenReused", err)
+	}
+	if err := repo.RevokeRefreshFamily(ctx, reused.FamilyID); err != nil {
+		t.Fatalf("RevokeRefreshFamily() error = %v", err)
+	}
+
+	// token terbaru milik penyerang atau korban ikut tidak berlaku setelah family dicabut
+	if _, err := repo.ConsumeRefreshToken(ctx, "token-2", testRefreshTTL); !errors.Is(err, ErrRefreshTokenNotFound) {
+		t.Fatalf("ConsumeRefreshToken() error = %v, want ErrRefreshTokenNotFound", err)
+	}
+}
+
+func store(t *testing.T, repo TokenRepository, token string, data *entity.RefreshToken) {
+	t.Helper()
+	if err := repo.StoreRefreshToken(context.Background(), token, data, testRefreshTTL); err != nil {
+		t.Fatalf("StoreRefreshToken() error = %v", err)
+	}
+}
+
+func consume(t *testing.T, repo TokenRepository, token string) {
+	t.Helper()
+	if _, err := repo.ConsumeRefreshToken(context.Background(), token, testRefreshTTL); err != nil {
+		t.Fatalf("ConsumeRefreshToken() error = %v", err)
+	}
+}
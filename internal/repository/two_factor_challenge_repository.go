@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
+	"github.com/redis/go-redis/v9"
+)
+
+var ErrTwoFactorChallengeNotFound = errors.New("challenge 2FA tidak ditemukan")
+
+const (
+	twoFactorChallengeKeyPrefix         = "two_factor_challenge:"
+	twoFactorChallengeAttemptsKeyPrefix = "two_factor_challenge_attempts:"
+)
+
+// TwoFactorChallengeRepository menyimpan challenge token yang menjembatani langkah password dan langkah kode 2FA
+type TwoFactorChallengeRepository interface {
+	Create(ctx context.Context, token string, data *entity.TwoFactorChallenge, ttl time.Duration) error
+	Find(ctx context.Context, token string) (*entity.TwoFactorChallenge, error)
+	RegisterFailure(ctx context.Context, token string) (int64, error)
+	Delete(ctx context.Context, token string) (bool, error)
+}
+
+type twoFactorChallengeRepositoryImpl struct {
+	redis *redis.Client
+}
+
+func NewTwoFactorChallengeRepository(redis *redis.Client) TwoFactorChallengeRepository {
+	return &twoFactorChallengeRepositoryImpl{redis: redis}
+}
+
+func (r *twoFactorChallengeRepositoryImpl) Create(ctx context.Context, token string, data *entity.TwoFactorChallenge, ttl time.Duration) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return r.redis.Set(ctx, twoFactorChallengeKeyPrefix+utils.HashToken(token), payload, ttl).Err()
+}
+
+func (r *twoFactorChallengeRepositoryImpl) Find(ctx context.Context, token string) (*entity.TwoFactorChallenge, error) {
+	payload, err := r.redis.Get(ctx, twoFactorChallengeKeyPrefix+utils.HashToken(token)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrTwoFactorChallengeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data := new(entity.TwoFactorChallenge)
+	if err := json.Unmarshal(payload, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// RegisterFailure menghitung kode salah untuk satu challenge; counter ikut kedaluwarsa bersama challenge-nya
+func (r *twoFactorChallengeRepositoryImpl) RegisterFailure(ctx context.Context, token string) (int64, error) {
+	hash := utils.HashToken(token)
+	ttl, err := r.redis.PTTL(ctx, twoFactorChallengeKeyPrefix+hash).Result()
+	if err != nil {
+		return 0, err
+	}
+	if ttl <= 0 {
+		return 0, ErrTwoFactorChallengeNotFound
+	}
+
+	pipe := r.redis.TxPipeline()
+	count := pipe.Incr(ctx, twoFactorChallengeAttemptsKeyPrefix+hash)
+	pipe.PExpire(ctx, twoFactorChallengeAttemptsKeyPrefix+hash, ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return count.Val(), nil
+}
+
+// Delete dipanggil setelah challenge berhasil diselesaikan. Nilai false berarti challenge sudah dipakai
+// oleh request lain yang berjalan bersamaan, sehingga token tidak boleh diterbitkan dua kali.
+func (r *twoFactorChallengeRepositoryImpl) Delete(ctx context.Context, token string) (bool, error) {
+	hash := utils.HashToken(token)
+	pipe := r.redis.TxPipeline()
+	deleted := pipe.Del(ctx, twoFactorChallengeKeyPrefix+hash)
+	pipe.Del(ctx, twoFactorChallengeAttemptsKeyPrefix+hash)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, err
+	}
+	return deleted.Val() == 1, nil
+}
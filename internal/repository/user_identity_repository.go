@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"gorm.io/gorm"
+)
+
+type UserIdentityRepository interface {
+	Create(db *gorm.DB, identity *entity.UserIdentity) error
+	FindByProviderSubject(provider, subject string) (*entity.UserIdentity, error)
+}
+
+type userIdentityRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewUserIdentityRepository(db *gorm.DB) UserIdentityRepository {
+	return &userIdentityRepositoryImpl{db: db}
+}
+
+func (r *userIdentityRepositoryImpl) Create(db *gorm.DB, identity *entity.UserIdentity) error {
+	return db.Create(identity).Error
+}
+
+func (r *userIdentityRepositoryImpl) FindByProviderSubject(provider, subject string) (*entity.UserIdentity, error) {
+	var identity entity.UserIdentity
+	err := r.db.Where("provider = ? AND subject = ?", provider, subject).First(&identity).Error
+	return &identity, err
+}
@@ -1,6 +1,9 @@
 package repository
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
 	"github.com/rs/zerolog"
 	"gorm.io/gorm"
@@ -9,12 +12,28 @@ import (
 type UserRepository interface {
 	Create(db *gorm.DB, entity *entity.User) error
 	CountByEmail(email string) (int64, error)
+	CountByUsername(username string) (int64, error)
 	FindByID(id uint) (*entity.User, error)
-	FindByToken(entity *entity.User, token string) error
 	FindByEmail(email string) (*entity.User, error)
+	FindByUsername(username string) (*entity.User, error)
+	FindGhost() (*entity.User, error)
 	FindAll() ([]entity.User, error)
 	Update(db *gorm.DB, entity *entity.User) error
-	Delete(id uint) error
+	UpdatePasswordHash(db *gorm.DB, id uint, oldHash, newHash string) (bool, error)
+	CountByPasswordHashParams() ([]PasswordHashParamsCount, error)
+	UpdateProfile(db *gorm.DB, id uint, fields map[string]interface{}) error
+	SetTOTP(db *gorm.DB, id uint, secret *string, enabledAt *time.Time) error
+	MarkEmailVerified(db *gorm.DB, id uint) error
+	ScheduleDeletion(db *gorm.DB, id uint, scheduledAt *time.Time, transferTo *uint, deleteComments bool) error
+	FindDueForDeletion(now time.Time, limit int) ([]entity.User, error)
+	Scrub(db *gorm.DB, id uint) error
+}
+
+// PasswordHashParamsCount adalah jumlah user untuk satu kombinasi parameter hash beserta panjang salt dan key,
+// misalnya "m=65536,t=1,p=2,s=16,k=32"
+type PasswordHashParamsCount struct {
+	Params string
+	Total  int64
 }
 
 type userRepositoryImpl struct {
@@ -32,16 +51,18 @@ func (r *userRepositoryImpl) CountByEmail(email string) (int64, error) {
 	return total, err
 }
 
+func (r *userRepositoryImpl) CountByUsername(username string) (int64, error) {
+	var total int64
+	err := r.db.Model(&entity.User{}).Where("username = ?", username).Count(&total).Error
+	return total, err
+}
+
 func (r *userRepositoryImpl) FindByID(id uint) (*entity.User, error) {
 	var user entity.User
 	err := r.db.First(&user, id).Error
 	return &user, err
 }
 
-func (r *userRepositoryImpl) FindByToken(entity *entity.User, token string) error {
-	return r.db.Where("token = ?", token).Find(&entity).Error
-}
-
 func (r *userRepositoryImpl) FindByEmail(email string) (*entity.User, error) {
 	var user entity.User
 	err := r.db.Model(&entity.User{}).Where("email = ?", email).First(&user).Error
@@ -49,6 +70,19 @@ func (r *userRepositoryImpl) FindByEmail(email string) (*entity.User, error) {
 	return &user, err
 }
 
+func (r *userRepositoryImpl) FindByUsername(username string) (*entity.User, error) {
+	var user entity.User
+	err := r.db.Where("username = ?", username).First(&user).Error
+	return &user, err
+}
+
+// FindGhost mencari user ghost lewat flag is_system dari db/migrations/000019_add_user_system_flag.up.sql
+func (r *userRepositoryImpl) FindGhost() (*entity.User, error) {
+	var user entity.User
+	err := r.db.Where("username = ? AND is_system", entity.GhostUsername).First(&user).Error
+	return &user, err
+}
+
 func (r *userRepositoryImpl) FindAll() ([]entity.User, error) { // <-- Implementasi metode baru
 	var users []entity.User
 	err := r.db.Find(&users).Error
@@ -59,8 +93,87 @@ func (r *userRepositoryImpl) Update(db *gorm.DB, entity *entity.User) error {
 	return db.Save(entity).Error
 }
 
-func (r *userRepositoryImpl) Delete(id uint) error {
-	return r.db.Delete(&entity.User{}, id).Error
+// UpdatePasswordHash mengganti hash hanya jika hash lama masih tersimpan; false berarti password sudah diganti di tempat lain
+func (r *userRepositoryImpl) UpdatePasswordHash(db *gorm.DB, id uint, oldHash, newHash string) (bool, error) {
+	result := db.Model(&entity.User{}).Where("id = ? AND password_hash = ?", id, oldHash).Update("password_hash", newHash)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *userRepositoryImpl) CountByPasswordHashParams() ([]PasswordHashParamsCount, error) {
+	var counts []PasswordHashParamsCount
+	err := r.db.Model(&entity.User{}).
+		// salt dan key disimpan dalam base64 tanpa padding, sehingga panjang byte-nya adalah panjang teks * 3 / 4
+		Select("format('%s,s=%s,k=%s', split_part(password_hash, '$', 4), " +
+			"length(split_part(password_hash, '$', 5)) * 3 / 4, " +
+			"length(split_part(password_hash, '$', 6)) * 3 / 4) AS params, count(*) AS total").
+		Group("params").
+		Order("total DESC").
+		Scan(&counts).Error
+	return counts, err
+}
+
+func (r *userRepositoryImpl) MarkEmailVerified(db *gorm.DB, id uint) error {
+	return db.Model(&entity.User{}).Where("id = ? AND email_verified_at IS NULL", id).Update("email_verified_at", time.Now()).Error
+}
+
+// UpdateProfile hanya menulis kolom profil publik yang dikirim, sehingga kolom sensitif tidak ikut tersimpan ulang
+func (r *userRepositoryImpl) UpdateProfile(db *gorm.DB, id uint, fields map[string]interface{}) error {
+	return db.Model(&entity.User{}).Where("id = ?", id).Updates(fields).Error
+}
+
+// SetTOTP menyimpan secret authenticator; enabledAt nil berarti pendaftaran belum dikonfirmasi dengan kode
+func (r *userRepositoryImpl) SetTOTP(db *gorm.DB, id uint, secret *string, enabledAt *time.Time) error {
+	return db.Model(&entity.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"totp_secret":     secret,
+		"totp_enabled_at": enabledAt,
+	}).Error
+}
+
+// ScheduleDeletion menjadwalkan penghapusan akun; scheduledAt nil membatalkan jadwal yang ada
+func (r *userRepositoryImpl) ScheduleDeletion(db *gorm.DB, id uint, scheduledAt *time.Time, transferTo *uint, deleteComments bool) error {
+	return db.Model(&entity.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"deletion_scheduled_at":    scheduledAt,
+		"deletion_transfer_to":     transferTo,
+		"deletion_delete_comments": deleteComments,
+	}).Error
+}
+
+func (r *userRepositoryImpl) FindDueForDeletion(now time.Time, limit int) ([]entity.User, error) {
+	var users []entity.User
+	err := r.db.Where("deletion_scheduled_at <= ?", now).Order("deletion_scheduled_at").Limit(limit).Find(&users).Error
+	return users, err
+}
+
+// Scrub menghapus data pribadi dari baris user tanpa menghapus barisnya, sehingga audit log tetap merujuk ke ID yang sama.
+// Kredensial yang terikat ke user ikut dihapus. Post dan komentar harus sudah dipindahkan sebelumnya.
+func (r *userRepositoryImpl) Scrub(db *gorm.DB, id uint) error {
+	placeholder := fmt.Sprintf("deleted-%d", id)
+	err := db.Model(&entity.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"username":                 placeholder,
+		"email":                    placeholder + "@deleted.invalid",
+		"password_hash":            "!",
+		"display_name":             "",
+		"bio":                      "",
+		"website":                  "",
+		"avatar_url":               "",
+		"totp_secret":              nil,
+		"totp_enabled_at":          nil,
+		"email_verified_at":        nil,
+		"deletion_scheduled_at":    nil,
+		"deletion_transfer_to":     nil,
+		"deletion_delete_comments": false,
+		"deleted_at":               time.Now(),
+	}).Error
+	if err != nil {
+		return err
+	}
+
+	for _, table := range []string{"user_identities", "api_keys", "two_factor_recovery_codes", "email_verification_tokens", "password_reset_tokens"} {
+		if err := db.Exec("DELETE FROM "+table+" WHERE user_id = ?", id).Error; err != nil {
+			return err
+		}
+	}
+	return nil
 }
 func NewUserRepository(log *zerolog.Logger, db *gorm.DB) UserRepository {
 	return &userRepositoryImpl{
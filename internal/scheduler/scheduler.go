@@ -0,0 +1,84 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Job dijalankan berulang setiap Interval. Run harus aman dijalankan bersamaan di beberapa replika,
+// misalnya dengan mengklaim pekerjaan memakai SELECT ... FOR UPDATE SKIP LOCKED.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler menjalankan job di dalam proses web. Stop membatalkan context job yang sedang berjalan
+// dan menunggu semuanya selesai.
+type Scheduler struct {
+	log    *zerolog.Logger
+	jobs   []Job
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func New(log *zerolog.Logger) *Scheduler {
+	return &Scheduler{log: log}
+}
+
+// Add mendaftarkan job; harus dipanggil sebelum Start
+func (s *Scheduler) Add(job Job) {
+	s.jobs = append(s.jobs, job)
+}
+
+func (s *Scheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, job)
+	}
+	s.log.Info().Msgf("Scheduler started with %d job(s)", len(s.jobs))
+}
+
+func (s *Scheduler) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.wg.Wait()
+	s.log.Info().Msg("Scheduler stopped")
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.run(ctx, job)
+		}
+	}
+}
+
+// run menangkap panic agar satu job yang gagal tidak menghentikan job lain maupun proses web
+func (s *Scheduler) run(ctx context.Context, job Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.log.Error().Msgf("Job %s panicked: %v", job.Name, r)
+		}
+	}()
+
+	if err := job.Run(ctx); err != nil && ctx.Err() == nil {
+		s.log.Error().Msgf("Job %s failed: %v", job.Name, err)
+	}
+}
@@ -0,0 +1,291 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/alexedwards/argon2id"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/repository"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/knadh/koanf"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+var ErrDeletionNotScheduled = fiber.NewError(fiber.StatusNotFound, "Penghapusan akun tidak dijadwalkan")
+
+// purgeBatchSize membatasi jumlah akun yang diproses satu kali PurgeDue
+const purgeBatchSize = 100
+
+// AccountDeletionUseCase menangani penghapusan akun. Permintaan dari user sendiri dijadwalkan dengan masa tenggang;
+// saat purge, post dipindahkan ke author lain atau user ghost, komentar dianonimkan atau dihapus,
+// lalu data pribadi dihapus dari baris user.
+type AccountDeletionUseCase interface {
+	Schedule(ctx context.Context, auth *model.Auth, request *model.AccountDeletionRequest) (*model.AccountDeletionResponse, error)
+	Get(auth *model.Auth) (*model.AccountDeletionResponse, error)
+	Cancel(auth *model.Auth) error
+	Delete(ctx context.Context, auth *model.Auth, user *entity.User) error
+	PurgeDue(ctx context.Context) (int, error)
+}
+
+type accountDeletionUseCaseImpl struct {
+	DB                *gorm.DB
+	Log               *zerolog.Logger
+	Validate          *validator.Validate
+	Audit             AuditUseCase
+	UserRepository    repository.UserRepository
+	PostRepository    repository.PostRepository
+	CommentRepository repository.CommentRepository
+	TokenRepository   repository.TokenRepository
+	SessionRepository repository.SessionRepository
+	cfg               *koanf.Koanf
+}
+
+func NewAccountDeletionUseCase(db *gorm.DB, log *zerolog.Logger, validate *validator.Validate, auditUseCase AuditUseCase, userRepository repository.UserRepository, postRepository repository.PostRepository, commentRepository repository.CommentRepository, tokenRepository repository.TokenRepository, sessionRepository repository.SessionRepository, config *koanf.Koanf) AccountDeletionUseCase {
+	return &accountDeletionUseCaseImpl{
+		DB:                db,
+		Log:               log,
+		Validate:          validate,
+		Audit:             auditUseCase,
+		UserRepository:    userRepository,
+		PostRepository:    postRepository,
+		CommentRepository: commentRepository,
+		TokenRepository:   tokenRepository,
+		SessionRepository: sessionRepository,
+		cfg:               config,
+	}
+}
+
+func (c *accountDeletionUseCaseImpl) Schedule(ctx context.Context, auth *model.Auth, request *model.AccountDeletionRequest) (*model.AccountDeletionResponse, error) {
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warn().Msgf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	user, err := c.findUser(auth.ID)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsSystem {
+		return nil, fiber.ErrForbidden
+	}
+	if user.DeletionScheduledAt != nil {
+		return nil, fiber.NewError(fiber.StatusConflict, "Penghapusan akun sudah dijadwalkan")
+	}
+
+	match, err := argon2id.ComparePasswordAndHash(request.Password, user.PasswordHash)
+	if err != nil || !match {
+		c.Log.Warn().Msgf("Account deletion for user %d rejected: password mismatch", user.ID)
+		return nil, fiber.NewError(fiber.StatusForbidden, "Password tidak sesuai")
+	}
+
+	var recipient *entity.User
+	if request.TransferTo != "" {
+		recipient, err = c.UserRepository.FindByUsername(request.TransferTo)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fiber.NewError(fiber.StatusBadRequest, "Penerima post tidak ditemukan")
+			}
+			c.Log.Error().Msgf("Failed to find post recipient: %v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		if !canReceivePosts(user, recipient) {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Post hanya dapat dipindahkan ke author atau admin lain yang aktif")
+		}
+	}
+
+	graceDays := c.cfg.Int("account_deletion.grace_period")
+	if graceDays <= 0 {
+		graceDays = 30
+	}
+	scheduledAt := time.Now().AddDate(0, 0, graceDays)
+	var transferTo *uint
+	if recipient != nil {
+		transferTo = &recipient.ID
+	}
+	if err := c.UserRepository.ScheduleDeletion(c.DB, user.ID, &scheduledAt, transferTo, request.DeleteComments); err != nil {
+		c.Log.Error().Msgf("Failed to schedule account deletion: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := &model.AccountDeletionResponse{
+		ScheduledAt:    scheduledAt,
+		TransferTo:     entity.GhostUsername,
+		DeleteComments: request.DeleteComments,
+	}
+	if recipient != nil {
+		response.TransferTo = recipient.Username
+	}
+
+	c.Audit.Record(auth, entity.AuditActionUserDeletionSchedule, entity.AuditTargetUser, user.ID, model.AuditChanges{
+		"scheduled_at":    {After: scheduledAt},
+		"transfer_to":     {After: response.TransferTo},
+		"delete_comments": {After: request.DeleteComments},
+	})
+	return response, nil
+}
+
+func (c *accountDeletionUseCaseImpl) Get(auth *model.Auth) (*model.AccountDeletionResponse, error) {
+	user, err := c.findUser(auth.ID)
+	if err != nil {
+		return nil, err
+	}
+	if user.DeletionScheduledAt == nil {
+		return nil, ErrDeletionNotScheduled
+	}
+
+	response := &model.AccountDeletionResponse{
+		ScheduledAt:    *user.DeletionScheduledAt,
+		TransferTo:     entity.GhostUsername,
+		DeleteComments: user.DeletionDeleteComments,
+	}
+	if recipient := c.recipient(user, nil); recipient != nil {
+		response.TransferTo = recipient.Username
+	}
+	return response, nil
+}
+
+func (c *accountDeletionUseCaseImpl) Cancel(auth *model.Auth) error {
+	user, err := c.findUser(auth.ID)
+	if err != nil {
+		return err
+	}
+	if user.DeletionScheduledAt == nil {
+		return ErrDeletionNotScheduled
+	}
+
+	if err := c.UserRepository.ScheduleDeletion(c.DB, user.ID, nil, nil, false); err != nil {
+		c.Log.Error().Msgf("Failed to cancel account deletion: %v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	c.Audit.Record(auth, entity.AuditActionUserDeletionCancel, entity.AuditTargetUser, user.ID, model.AuditChanges{
+		"scheduled_at": {Before: *user.DeletionScheduledAt},
+	})
+	return nil
+}
+
+// Delete langsung menjalankan purge tanpa masa tenggang, dipakai admin. Pilihan yang sudah dijadwalkan user tetap dihormati.
+func (c *accountDeletionUseCaseImpl) Delete(ctx context.Context, auth *model.Auth, user *entity.User) error {
+	return c.purge(ctx, auth, user)
+}
+
+// PurgeDue menjalankan purge untuk akun yang masa tenggangnya sudah lewat. Kegagalan satu akun tidak menghentikan
+// akun lainnya; jumlah akun yang berhasil dikembalikan bersama gabungan error.
+func (c *accountDeletionUseCaseImpl) PurgeDue(ctx context.Context) (int, error) {
+	users, err := c.UserRepository.FindDueForDeletion(time.Now(), purgeBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	var errs []error
+	for i := range users {
+		if err := c.purge(ctx, nil, &users[i]); err != nil {
+			c.Log.Error().Msgf("Failed to purge user %d: %v", users[i].ID, err)
+			errs = append(errs, err)
+			continue
+		}
+		purged++
+	}
+	return purged, errors.Join(errs...)
+}
+
+func (c *accountDeletionUseCaseImpl) purge(ctx context.Context, auth *model.Auth, user *entity.User) error {
+	ghost, err := c.UserRepository.FindGhost()
+	if err != nil {
+		c.Log.Error().Msgf("Failed to find ghost user, run migration 000019: %v", err)
+		return fiber.ErrInternalServerError
+	}
+	if user.ID == ghost.ID {
+		return fiber.NewError(fiber.StatusForbidden, "User ghost tidak dapat dihapus")
+	}
+	recipient := c.recipient(user, ghost)
+
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	posts, err := c.PostRepository.ReassignAuthor(tx, user.ID, recipient.ID)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to reassign posts: %v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	var comments int64
+	commentAction := "anonymized"
+	if user.DeletionDeleteComments {
+		commentAction = "deleted"
+		comments, err = c.CommentRepository.DeleteByAuthorID(tx, user.ID)
+	} else {
+		comments, err = c.CommentRepository.ReassignAuthor(tx, user.ID, ghost.ID)
+	}
+	if err != nil {
+		c.Log.Error().Msgf("Failed to %s comments: %v", commentAction, err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := c.UserRepository.Scrub(tx, user.ID); err != nil {
+		c.Log.Error().Msgf("Failed to scrub user: %v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Error().Msgf("Failed to commit account deletion: %v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	// akun sudah tidak bisa login setelah commit; kegagalan mencabut token hanya dicatat karena token akan kedaluwarsa sendiri
+	if _, err := c.TokenRepository.IncrementTokenVersion(ctx, user.ID); err != nil {
+		c.Log.Error().Msgf("Failed to increment token version: %v", err)
+	}
+	if err := c.TokenRepository.RevokeAllRefreshFamilies(ctx, user.ID); err != nil {
+		c.Log.Error().Msgf("Failed to revoke refresh token families: %v", err)
+	}
+	if _, err := c.SessionRepository.DeleteByUserID(ctx, user.ID); err != nil {
+		c.Log.Error().Msgf("Failed to delete sessions: %v", err)
+	}
+
+	changes := auditUserSnapshot(user)
+	changes["posts"] = model.AuditChange{After: map[string]interface{}{"count": posts, "transferred_to": recipient.Username}}
+	changes["comments"] = model.AuditChange{After: map[string]interface{}{"count": comments, "action": commentAction}}
+	c.Audit.Record(auth, entity.AuditActionUserDelete, entity.AuditTargetUser, user.ID, changes)
+
+	c.Log.Info().Msgf("User %d deleted: %d posts transferred to %s, %d comments %s", user.ID, posts, recipient.Username, comments, commentAction)
+	return nil
+}
+
+// recipient mengembalikan penerima post yang dipilih user jika masih memenuhi syarat, atau fallback
+func (c *accountDeletionUseCaseImpl) recipient(user, fallback *entity.User) *entity.User {
+	if user.DeletionTransferTo == nil {
+		return fallback
+	}
+	recipient, err := c.UserRepository.FindByID(*user.DeletionTransferTo)
+	if err != nil || !canReceivePosts(user, recipient) {
+		c.Log.Warn().Msgf("Post recipient %d for user %d is no longer eligible", *user.DeletionTransferTo, user.ID)
+		return fallback
+	}
+	return recipient
+}
+
+func (c *accountDeletionUseCaseImpl) findUser(id uint) (*entity.User, error) {
+	user, err := c.UserRepository.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.ErrNotFound
+		}
+		c.Log.Error().Msgf("Failed to find user: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	return user, nil
+}
+
+func canReceivePosts(user, recipient *entity.User) bool {
+	return recipient.ID != user.ID &&
+		!recipient.IsSystem &&
+		recipient.DeletionScheduledAt == nil &&
+		(recipient.Role == entity.UserRoleAuthor || recipient.Role == entity.UserRoleAdmin)
+}
@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model/converter"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/repository"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+// APIKeyPrefix menandai bearer token sebagai API key, bukan JWT. Format key: blog_<prefix>_<secret>
+const APIKeyPrefix = "blog_"
+
+// apiKeyTouchInterval membatasi penulisan last_used_at agar tidak terjadi UPDATE di setiap request
+const apiKeyTouchInterval = time.Minute
+
+type APIKeyUseCase interface {
+	Create(ctx context.Context, auth *model.Auth, request *model.CreateAPIKeyRequest) (*model.APIKeyResponse, error)
+	List(ctx context.Context, auth *model.Auth) ([]model.APIKeyResponse, error)
+	Revoke(ctx context.Context, auth *model.Auth, id uint) error
+	Authenticate(ctx context.Context, key string) (*model.Auth, error)
+}
+
+type apiKeyUseCaseImpl struct {
+	Log              *zerolog.Logger
+	Validate         *validator.Validate
+	APIKeyRepository repository.APIKeyRepository
+	UserRepository   repository.UserRepository
+}
+
+func NewAPIKeyUseCase(log *zerolog.Logger, validate *validator.Validate, apiKeyRepository repository.APIKeyRepository, userRepository repository.UserRepository) APIKeyUseCase {
+	return &apiKeyUseCaseImpl{
+		Log:              log,
+		Validate:         validate,
+		APIKeyRepository: apiKeyRepository,
+		UserRepository:   userRepository,
+	}
+}
+
+// Create mengembalikan key lengkap satu kali saja; setelahnya hanya prefix yang bisa dilihat
+func (c *apiKeyUseCaseImpl) Create(ctx context.Context, auth *model.Auth, request *model.CreateAPIKeyRequest) (*model.APIKeyResponse, error) {
+	err := c.Validate.Struct(request)
+	if err != nil {
+		c.Log.Warn().Msgf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	if request.ExpiresAt != nil && !request.ExpiresAt.After(time.Now()) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "expires_at harus di masa depan")
+	}
+
+	prefix, err := utils.GenerateRandomToken(6)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to generate api key prefix: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	// prefix tidak boleh mengandung "_" karena dipakai sebagai pemisah
+	prefix = strings.ReplaceAll(prefix, "_", "x")
+
+	secret, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to generate api key secret: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	key := APIKeyPrefix + prefix + "_" + secret
+
+	apiKey := &entity.APIKey{
+		UserID:    auth.ID,
+		Name:      request.Name,
+		Prefix:    prefix,
+		KeyHash:   utils.HashToken(key),
+		Scopes:    strings.Join(uniqueScopes(request.Scopes), " "),
+		ExpiresAt: request.ExpiresAt,
+	}
+
+	if err := c.APIKeyRepository.Create(apiKey); err != nil {
+		c.Log.Error().Msgf("Failed to create api key: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := converter.APIKeyToResponse(apiKey)
+	response.Key = key
+	return response, nil
+}
+
+func (c *apiKeyUseCaseImpl) List(ctx context.Context, auth *model.Auth) ([]model.APIKeyResponse, error) {
+	keys, err := c.APIKeyRepository.FindByUserID(auth.ID)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to list api keys: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.APIKeyResponse, 0, len(keys))
+	for i := range keys {
+		responses = append(responses, *converter.APIKeyToResponse(&keys[i]))
+	}
+	return responses, nil
+}
+
+func (c *apiKeyUseCaseImpl) Revoke(ctx context.Context, auth *model.Auth, id uint) error {
+	revoked, err := c.APIKeyRepository.Revoke(id, auth.ID)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to revoke api key: %v", err)
+		return fiber.ErrInternalServerError
+	}
+	if !revoked {
+		return fiber.ErrNotFound
+	}
+	return nil
+}
+
+// Authenticate memvalidasi API key dan mengembalikan subject dengan role user saat ini serta scope milik key
+func (c *apiKeyUseCaseImpl) Authenticate(ctx context.Context, key string) (*model.Auth, error) {
+	parts := strings.SplitN(strings.TrimPrefix(key, APIKeyPrefix), "_", 2)
+	if !strings.HasPrefix(key, APIKeyPrefix) || len(parts) != 2 {
+		return nil, fiber.ErrUnauthorized
+	}
+
+	apiKey, err := c.APIKeyRepository.FindByPrefix(parts[0])
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.ErrUnauthorized
+		}
+		c.Log.Error().Msgf("Failed to find api key: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if subtle.ConstantTimeCompare([]byte(apiKey.KeyHash), []byte(utils.HashToken(key))) != 1 {
+		return nil, fiber.ErrUnauthorized
+	}
+
+	now := time.Now()
+	if !apiKey.IsActive(now) {
+		return nil, fiber.ErrUnauthorized
+	}
+
+	user, err := c.UserRepository.FindByID(apiKey.UserID)
+	if err != nil {
+		c.Log.Warn().Msgf("Owner of api key %d not found: %v", apiKey.ID, err)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyTouchInterval {
+		if err := c.APIKeyRepository.TouchLastUsed(apiKey.ID, now); err != nil {
+			c.Log.Error().Msgf("Failed to update api key last used: %v", err)
+		}
+	}
+
+	return &model.Auth{
+		ID:       user.ID,
+		Role:     string(user.Role),
+		APIKeyID: apiKey.ID,
+		Scopes:   apiKey.ScopeList(),
+	}, nil
+}
+
+func uniqueScopes(scopes []string) []string {
+	seen := make(map[string]bool, len(scopes))
+	result := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !seen[scope] {
+			seen[scope] = true
+			result = append(result, scope)
+		}
+	}
+	return result
+}
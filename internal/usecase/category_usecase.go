@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/policy"
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/repository"
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
 	"github.com/go-playground/validator/v10"
@@ -13,23 +15,28 @@ import (
 )
 
 type CategoryService interface {
-	CreateCategory(name string) (*entity.Category, error)
+	CreateCategory(name string, auth *model.Auth) (*entity.Category, error)
 	GetAllCategories() ([]entity.Category, error)
 	GetCategoryByID(id uint) (*entity.Category, error)
-	UpdateCategory(id uint, name string) (*entity.Category, error)
-	DeleteCategory(id uint) error
+	UpdateCategory(id uint, name string, auth *model.Auth) (*entity.Category, error)
+	DeleteCategory(id uint, auth *model.Auth) error
 }
 
 type categoryServiceImpl struct {
 	categoryRepo repository.CategoryRepository
+	policy       *policy.Engine
 	validator    *validator.Validate
 }
 
-func NewCategoryUseCase(categoryRepo repository.CategoryRepository, validator *validator.Validate) CategoryService {
-	return &categoryServiceImpl{categoryRepo: categoryRepo, validator: validator}
+func NewCategoryUseCase(categoryRepo repository.CategoryRepository, policyEngine *policy.Engine, validator *validator.Validate) CategoryService {
+	return &categoryServiceImpl{categoryRepo: categoryRepo, policy: policyEngine, validator: validator}
 }
 
-func (s *categoryServiceImpl) CreateCategory(name string) (*entity.Category, error) {
+func (s *categoryServiceImpl) CreateCategory(name string, auth *model.Auth) (*entity.Category, error) {
+	if err := s.policy.Authorize(auth, policy.ActionCreate, policy.Resource{Type: policy.ResourceCategory}); err != nil {
+		return nil, err
+	}
+
 	slug := utils.GenerateSlug(name)
 
 	existingCategoryByName, err := s.categoryRepo.FindByName(name)
@@ -81,7 +88,11 @@ func (s *categoryServiceImpl) GetCategoryByID(id uint) (*entity.Category, error)
 	return category, nil
 }
 
-func (s *categoryServiceImpl) UpdateCategory(id uint, name string) (*entity.Category, error) {
+func (s *categoryServiceImpl) UpdateCategory(id uint, name string, auth *model.Auth) (*entity.Category, error) {
+	if err := s.policy.Authorize(auth, policy.ActionUpdate, policy.Resource{Type: policy.ResourceCategory}); err != nil {
+		return nil, err
+	}
+
 	category, err := s.categoryRepo.FindByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -121,7 +132,11 @@ func (s *categoryServiceImpl) UpdateCategory(id uint, name string) (*entity.Cate
 }
 
 // DeleteCategory menghapus kategori
-func (s *categoryServiceImpl) DeleteCategory(id uint) error {
+func (s *categoryServiceImpl) DeleteCategory(id uint, auth *model.Auth) error {
+	if err := s.policy.Authorize(auth, policy.ActionDelete, policy.Resource{Type: policy.ResourceCategory}); err != nil {
+		return err
+	}
+
 	_, err := s.categoryRepo.FindByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -4,6 +4,8 @@ import (
 	"errors"
 
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/policy"
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/repository"
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
 	"github.com/go-playground/validator/v10"
@@ -11,24 +13,35 @@ import (
 )
 
 type CommentUseCase interface {
-	CreateComment(content string, postID, authorID uint) (*entity.Comment, error)
+	CreateComment(content string, postID uint, auth *model.Auth) (*entity.Comment, error)
 	GetCommentsByPostID(postID uint) ([]entity.Comment, error)
-	UpdateComment(commentID, authorID uint, content string) (*entity.Comment, error)
-	DeleteComment(commentID, authorID uint) error
+	UpdateComment(commentID uint, auth *model.Auth, content string) (*entity.Comment, error)
+	DeleteComment(commentID uint, auth *model.Auth) error
 }
 
 type commentUseCaseImpl struct {
 	commentRepo repository.CommentRepository
 	postRepo    repository.PostRepository 
+	userRepo    repository.UserRepository
+	policy      *policy.Engine
+	audit       AuditUseCase
 	validator   *validator.Validate
 }
 
-func NewCommentUseCase(commentRepo repository.CommentRepository, postRepo repository.PostRepository, validator *validator.Validate) CommentUseCase {
-	return &commentUseCaseImpl{commentRepo: commentRepo, postRepo: postRepo, validator: validator}
+func NewCommentUseCase(commentRepo repository.CommentRepository, postRepo repository.PostRepository, userRepo repository.UserRepository, policyEngine *policy.Engine, auditUseCase AuditUseCase, validator *validator.Validate) CommentUseCase {
+	return &commentUseCaseImpl{commentRepo: commentRepo, postRepo: postRepo, userRepo: userRepo, policy: policyEngine, audit: auditUseCase, validator: validator}
 }
 
-func (s *commentUseCaseImpl) CreateComment(content string, postID, authorID uint) (*entity.Comment, error) {
-	_, err := s.postRepo.FindByID(postID)
+func (s *commentUseCaseImpl) CreateComment(content string, postID uint, auth *model.Auth) (*entity.Comment, error) {
+	if err := s.policy.Authorize(auth, policy.ActionCreate, policy.Resource{Type: policy.ResourceComment}); err != nil {
+		return nil, err
+	}
+
+	if err := ensureEmailVerified(s.userRepo, auth.ID); err != nil {
+		return nil, err
+	}
+
+	_, err := s.postRepo.FindPublishedByID(postID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, utils.ErrNotFound("Postingan") 
@@ -39,7 +52,7 @@ func (s *commentUseCaseImpl) CreateComment(content string, postID, authorID uint
 	comment := &entity.Comment{
 		Content:  content,
 		PostID:   postID,
-		AuthorID: authorID,
+		AuthorID: auth.ID,
 	}
 
 	err = s.commentRepo.Create(comment)
@@ -53,7 +66,7 @@ func (s *commentUseCaseImpl) CreateComment(content string, postID, authorID uint
 // GetCommentsByPostID mengambil semua komentar untuk postingan tertentu
 func (s *commentUseCaseImpl) GetCommentsByPostID(postID uint) ([]entity.Comment, error) {
 	// Validasi apakah postID valid (apakah postingan ada)
-	_, err := s.postRepo.FindByID(postID)
+	_, err := s.postRepo.FindPublishedByID(postID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, utils.ErrNotFound("Postingan")
@@ -68,7 +81,7 @@ func (s *commentUseCaseImpl) GetCommentsByPostID(postID uint) ([]entity.Comment,
 	return comments, nil
 }
 
-func (s *commentUseCaseImpl) UpdateComment(commentID, authorID uint, content string) (*entity.Comment, error) {
+func (s *commentUseCaseImpl) UpdateComment(commentID uint, auth *model.Auth, content string) (*entity.Comment, error) {
 	comment, err := s.commentRepo.FindByID(commentID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -77,9 +90,8 @@ func (s *commentUseCaseImpl) UpdateComment(commentID, authorID uint, content str
 		return nil, errors.New("Gagal menemukan komentar: " + err.Error())
 	}
 
-	// Otorisasi: Hanya penulis komentar yang bisa mengupdate
-	if comment.AuthorID != authorID {
-		return nil, utils.ErrForbidden("Anda tidak memiliki izin untuk memperbarui komentar ini")
+	if err := s.policy.Authorize(auth, policy.ActionUpdate, commentResource(comment)); err != nil {
+		return nil, err
 	}
 
 
@@ -92,7 +104,7 @@ func (s *commentUseCaseImpl) UpdateComment(commentID, authorID uint, content str
 }
 
 // DeleteComment menghapus komentar
-func (s *commentUseCaseImpl) DeleteComment(commentID, authorID uint) error {
+func (s *commentUseCaseImpl) DeleteComment(commentID uint, auth *model.Auth) error {
 	comment, err := s.commentRepo.FindByID(commentID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -101,15 +113,24 @@ func (s *commentUseCaseImpl) DeleteComment(commentID, authorID uint) error {
 		return errors.New("Gagal menemukan komentar: " + err.Error())
 	}
 
-	// Otorisasi: Hanya penulis komentar atau admin postingan terkait yang bisa menghapus
-	// Untuk saat ini, hanya penulis komentar. Logika admin bisa ditambahkan di sini.
-	if comment.AuthorID != authorID {
-		return utils.ErrForbidden("Anda tidak memiliki izin untuk menghapus komentar ini")
+	// Penulis komentar, pemilik postingan, dan admin boleh menghapus (lihat policy.DefaultRules)
+	if err := s.policy.Authorize(auth, policy.ActionDelete, commentResource(comment)); err != nil {
+		return err
 	}
 
 	err = s.commentRepo.Delete(commentID)
 	if err != nil {
 		return errors.New("Gagal menghapus komentar: " + err.Error())
 	}
+
+	// isi komentar tidak dicatat karena audit_logs append-only dan bisa memuat data pribadi penulisnya
+	s.audit.Record(auth, entity.AuditActionCommentDelete, entity.AuditTargetComment, comment.ID, model.AuditChanges{
+		"post_id":   {Before: comment.PostID},
+		"author_id": {Before: comment.AuthorID},
+	})
 	return nil
-}
\ No newline at end of file
+}
+
+func commentResource(comment *entity.Comment) policy.Resource {
+	return policy.Resource{Type: policy.ResourceComment, OwnerID: comment.AuthorID, ParentOwnerID: comment.Post.AuthorID}
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/gateway/messaging"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/repository"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+// publishBatchSize membatasi jumlah post yang diklaim satu transaksi agar lock tidak ditahan terlalu lama
+const publishBatchSize = 100
+
+// PostPublishingUseCase menerbitkan post terjadwal yang waktunya sudah tiba, dijalankan oleh internal/scheduler
+type PostPublishingUseCase interface {
+	PublishDue(ctx context.Context) error
+}
+
+type postPublishingUseCaseImpl struct {
+	DB             *gorm.DB
+	Log            *zerolog.Logger
+	Audit          AuditUseCase
+	PostRepository repository.PostRepository
+	Producer       *messaging.Producer[*model.PostEvent]
+}
+
+func NewPostPublishingUseCase(db *gorm.DB, log *zerolog.Logger, auditUseCase AuditUseCase, postRepository repository.PostRepository, producer *messaging.Producer[*model.PostEvent]) PostPublishingUseCase {
+	return &postPublishingUseCaseImpl{
+		DB:             db,
+		Log:            log,
+		Audit:          auditUseCase,
+		PostRepository: postRepository,
+		Producer:       producer,
+	}
+}
+
+// PublishDue mengulang klaim per batch sampai tidak ada post yang jatuh tempo. Event dikirim setelah commit
+// sehingga subscriber tidak pernah menerima post yang transaksinya dibatalkan.
+func (c *postPublishingUseCaseImpl) PublishDue(ctx context.Context) error {
+	for {
+		posts, err := c.claimBatch(ctx)
+		if err != nil {
+			return err
+		}
+
+		for i := range posts {
+			post := &posts[i]
+			c.Audit.Record(nil, entity.AuditActionPostStatus, entity.AuditTargetPost, post.ID, model.AuditChanges{
+				"status": {Before: string(entity.PostStatusScheduled), After: string(entity.PostStatusPublished)},
+			})
+			if err := c.Producer.Send(ctx, postToEvent(post)); err != nil {
+				c.Log.Error().Msgf("Failed to send post published event for post %d: %v", post.ID, err)
+			}
+		}
+		if len(posts) > 0 {
+			c.Log.Info().Msgf("Published %d scheduled post(s)", len(posts))
+		}
+
+		if len(posts) < publishBatchSize || ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+func (c *postPublishingUseCaseImpl) claimBatch(ctx context.Context) ([]entity.Post, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	posts, err := c.PostRepository.ClaimDueScheduled(tx, time.Now(), publishBatchSize)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+func postToEvent(post *entity.Post) *model.PostEvent {
+	return &model.PostEvent{
+		ID:          post.ID,
+		Title:       post.Title,
+		Slug:        post.Slug,
+		AuthorID:    post.AuthorID,
+		PublishedAt: post.PublishedAt,
+	}
+}
@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/policy"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
+	"gorm.io/gorm"
+)
+
+// revisionDiffContext adalah jumlah baris tak berubah yang ditampilkan di sekitar setiap perubahan
+const revisionDiffContext = 3
+
+// GetPostRevisions mengembalikan riwayat revisi post, hanya untuk user yang boleh mengubah post tersebut
+func (s *PostUseCaseImpl) GetPostRevisions(id uint, auth *model.Auth) ([]entity.PostRevision, error) {
+	if _, err := s.findEditablePost(id, auth); err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.PostRevisionRepository.FindByPostID(id)
+	if err != nil {
+		return nil, errors.New("gagal mengambil daftar revisi")
+	}
+	return revisions, nil
+}
+
+// DiffPostRevisions membandingkan judul, kategori, dan konten dua revisi dalam format unified diff
+func (s *PostUseCaseImpl) DiffPostRevisions(id uint, from, to int, auth *model.Auth) (*model.PostRevisionDiffResponse, error) {
+	if _, err := s.findEditablePost(id, auth); err != nil {
+		return nil, err
+	}
+
+	fromRevision, err := s.findPostRevision(id, from)
+	if err != nil {
+		return nil, err
+	}
+	toRevision, err := s.findPostRevision(id, to)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := utils.UnifiedDiff(fmt.Sprintf("revisi %d", from), fmt.Sprintf("revisi %d", to), revisionText(fromRevision), revisionText(toRevision), revisionDiffContext)
+	if err != nil {
+		if errors.Is(err, utils.ErrDiffTooLarge) {
+			return nil, utils.ErrValidation(err.Error())
+		}
+		return nil, err
+	}
+
+	return &model.PostRevisionDiffResponse{
+		From: from,
+		To:   to,
+		Diff: diff,
+	}, nil
+}
+
+// RestorePostRevision mengembalikan isi post ke revisi lama. Riwayat tidak ditimpa: pemulihan dicatat sebagai revisi baru.
+func (s *PostUseCaseImpl) RestorePostRevision(id uint, revision int, auth *model.Auth) (*entity.Post, error) {
+	current, err := s.findEditablePost(id, auth)
+	if err != nil {
+		return nil, err
+	}
+
+	restored, err := s.findPostRevision(id, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	before := current.Title
+	categoryNames := restored.Categories
+	post, err := s.UpdatePost(id, &restored.Title, &restored.Content, &categoryNames, auth)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Audit.Record(auth, entity.AuditActionPostRevisionRestore, entity.AuditTargetPost, post.ID, model.AuditChanges{
+		"title":    {Before: before, After: post.Title},
+		"revision": {After: revision},
+	})
+	return post, nil
+}
+
+// recordRevision menyimpan isi post setelah diubah. Jika post belum punya revisi (dibuat sebelum fitur ini ada),
+// isi sebelum perubahan disimpan lebih dulu sebagai revisi pertama agar tetap bisa dipulihkan.
+func (s *PostUseCaseImpl) recordRevision(tx *gorm.DB, post *entity.Post, previous *entity.PostRevision, auth *model.Auth) error {
+	total, err := s.PostRevisionRepository.CountByPostID(tx, post.ID)
+	if err != nil {
+		return err
+	}
+	if total == 0 {
+		if err := s.PostRevisionRepository.Create(tx, previous); err != nil {
+			return err
+		}
+	}
+	return s.PostRevisionRepository.Create(tx, newPostRevision(post, &auth.ID))
+}
+
+func (s *PostUseCaseImpl) findEditablePost(id uint, auth *model.Auth) (*entity.Post, error) {
+	post, err := s.PostRepository.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound("postingan")
+		}
+		return nil, errors.New("gagal menemukan postingan")
+	}
+
+	if err := s.Policy.Authorize(auth, policy.ActionUpdate, postResource(post)); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+func (s *PostUseCaseImpl) findPostRevision(id uint, revision int) (*entity.PostRevision, error) {
+	postRevision, err := s.PostRevisionRepository.FindByRevision(id, revision)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound(fmt.Sprintf("revisi %d", revision))
+		}
+		return nil, errors.New("gagal mengambil revisi")
+	}
+	return postRevision, nil
+}
+
+// newPostRevision menyalin isi post saat ini; waktu revisi mengikuti updated_at post jika sudah ada
+func newPostRevision(post *entity.Post, editorID *uint) *entity.PostRevision {
+	categories := make([]string, len(post.Categories))
+	for i, category := range post.Categories {
+		categories[i] = category.Name
+	}
+
+	revision := &entity.PostRevision{
+		PostID:     post.ID,
+		Title:      post.Title,
+		Content:    post.Content,
+		Categories: categories,
+		EditorID:   editorID,
+	}
+	if post.UpdatedAt != nil {
+		revision.CreatedAt = *post.UpdatedAt
+	}
+	return revision
+}
+
+// revisionText menyusun revisi menjadi satu teks sehingga perubahan judul dan kategori ikut terlihat di diff
+func revisionText(revision *entity.PostRevision) string {
+	return "Judul: " + revision.Title + "\nKategori: " + strings.Join(revision.Categories, ", ") + "\n\n" + revision.Content + "\n"
+}
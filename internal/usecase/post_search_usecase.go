@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"errors"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model/converter"
+)
+
+// defaultSearchLanguage dipakai jika lang tidak diisi; sebagian besar konten blog ini berbahasa Indonesia
+const defaultSearchLanguage = "id"
+
+// SearchPosts mencari post yang sudah terbit dan mengurutkannya berdasarkan relevansi
+func (s *PostUseCaseImpl) SearchPosts(request *model.SearchPostRequest) (*model.PageResponse[model.PostSearchResponse], error) {
+	language := request.Language
+	if language == "" {
+		language = defaultSearchLanguage
+	}
+
+	results, total, err := s.PostRepository.Search(language, request.Query, (request.Page-1)*request.Limit, request.Limit)
+	if err != nil {
+		s.Log.Error().Msgf("Failed to search posts: %v", err)
+		return nil, errors.New("gagal mencari postingan")
+	}
+
+	data := make([]model.PostSearchResponse, len(results))
+	for i := range results {
+		data[i] = *converter.PostSearchResultToResponse(&results[i].Post, results[i].Rank, results[i].Headline)
+	}
+
+	return &model.PageResponse[model.PostSearchResponse]{
+		Data: data,
+		PageMetadata: model.NewPageMetadata(request.Page, request.Limit, total),
+	}, nil
+}
@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/policy"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
+	"gorm.io/gorm"
+)
+
+// draftStatuses adalah status yang muncul di daftar "draft saya": belum terbit, termasuk yang sudah dijadwalkan
+var draftStatuses = []entity.PostStatus{entity.PostStatusDraft, entity.PostStatusScheduled}
+
+// PublishPost menerbitkan post sekarang, atau menjadwalkannya jika publishAt berada di masa depan
+func (s *PostUseCaseImpl) PublishPost(id uint, publishAt *time.Time, auth *model.Auth) (*entity.Post, error) {
+	now := time.Now()
+	if publishAt != nil && publishAt.After(now) {
+		return s.changePostStatus(id, entity.PostStatusScheduled, auth, func(*entity.Post) *time.Time { return publishAt })
+	}
+	return s.changePostStatus(id, entity.PostStatusPublished, auth, func(*entity.Post) *time.Time { return &now })
+}
+
+// UnpublishPost mengembalikan post ke draft sehingga tidak lagi terlihat publik
+func (s *PostUseCaseImpl) UnpublishPost(id uint, auth *model.Auth) (*entity.Post, error) {
+	return s.changePostStatus(id, entity.PostStatusDraft, auth, func(*entity.Post) *time.Time { return nil })
+}
+
+// ArchivePost menyembunyikan post dari publik tanpa menghapusnya; published_at tetap disimpan sebagai riwayat
+func (s *PostUseCaseImpl) ArchivePost(id uint, auth *model.Auth) (*entity.Post, error) {
+	return s.changePostStatus(id, entity.PostStatusArchived, auth, func(post *entity.Post) *time.Time { return post.PublishedAt })
+}
+
+// GetMyDrafts mengembalikan post milik user yang belum terbit beserta totalnya untuk paginasi
+func (s *PostUseCaseImpl) GetMyDrafts(auth *model.Auth, page, limit int) ([]entity.Post, int64, error) {
+	posts, total, err := s.PostRepository.FindByAuthorAndStatuses(auth.ID, draftStatuses, (page-1)*limit, limit)
+	if err != nil {
+		return nil, 0, errors.New("gagal mengambil daftar draft")
+	}
+	return posts, total, nil
+}
+
+// changePostStatus memeriksa izin dan transisi status, lalu menyimpan status baru dengan published_at dari publishedAt
+func (s *PostUseCaseImpl) changePostStatus(id uint, next entity.PostStatus, auth *model.Auth, publishedAt func(post *entity.Post) *time.Time) (*entity.Post, error) {
+	post, err := s.PostRepository.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound("postingan")
+		}
+		return nil, errors.New("gagal menemukan postingan")
+	}
+
+	if err := s.Policy.Authorize(auth, policy.ActionUpdate, postResource(post)); err != nil {
+		return nil, err
+	}
+
+	if !post.Status.CanTransitionTo(next) {
+		return nil, utils.ErrValidation(fmt.Sprintf("Postingan berstatus %s tidak dapat diubah menjadi %s", post.Status, next))
+	}
+	nextPublishedAt := publishedAt(post)
+
+	updated, err := s.PostRepository.UpdateStatus(post.ID, post.Status, next, nextPublishedAt)
+	if err != nil {
+		return nil, errors.New("Gagal mengubah status postingan: " + err.Error())
+	}
+	if !updated {
+		return nil, utils.ErrValidation("Status postingan baru saja diubah, muat ulang lalu coba lagi")
+	}
+
+	changes := model.AuditChanges{}
+	changes.Set("status", string(post.Status), string(next))
+	changes.Set("published_at", post.PublishedAt, nextPublishedAt)
+	s.Audit.Record(auth, entity.AuditActionPostStatus, entity.AuditTargetPost, post.ID, changes)
+
+	post.Status = next
+	post.PublishedAt = nextPublishedAt
+
+	// post terjadwal dikirim sebagai event oleh PostPublishingUseCase saat waktunya tiba
+	if next == entity.PostStatusPublished {
+		if err := s.Producer.Send(context.Background(), postToEvent(post)); err != nil {
+			s.Log.Error().Msgf("Failed to send post published event for post %d: %v", post.ID, err)
+		}
+	}
+	return post, nil
+}
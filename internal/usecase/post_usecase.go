@@ -2,32 +2,58 @@ package usecase
 
 import (
 	"errors"
-	"fmt"
 	"time"
 
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/gateway/messaging"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model/converter"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/policy"
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/repository"
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
 	"github.com/go-playground/validator/v10"
+	"github.com/rs/zerolog"
 	"gorm.io/gorm"
 )
 
 type PostUseCase interface {
-	CreatePost(title, content string, authorID uint, categoryNames []string) (*entity.Post, error)
+	CreatePost(title, content string, auth *model.Auth, categoryNames []string) (*entity.Post, error)
 	GetPostByID(id uint) (*entity.Post, error)
 	GetPostBySlug(slug string) (*entity.Post, error)
-	GetAllPosts(page, limit int) ([]entity.Post, error)
-	UpdatePost(id uint, title, content *string, publishedAt *time.Time, categoryNames *[]string, authorID uint) (*entity.Post, error)
-	DeletePost(id uint, authorID uint) error
+	GetAllPosts(request *model.ListPostRequest, auth *model.Auth) (*model.PageResponse[model.PostResponse], error)
+	SearchPosts(request *model.SearchPostRequest) (*model.PageResponse[model.PostSearchResponse], error)
+	UpdatePost(id uint, title, content *string, categoryNames *[]string, auth *model.Auth) (*entity.Post, error)
+	DeletePost(id uint, auth *model.Auth) error
+	PublishPost(id uint, publishAt *time.Time, auth *model.Auth) (*entity.Post, error)
+	UnpublishPost(id uint, auth *model.Auth) (*entity.Post, error)
+	ArchivePost(id uint, auth *model.Auth) (*entity.Post, error)
+	GetMyDrafts(auth *model.Auth, page, limit int) ([]entity.Post, int64, error)
+	GetPostRevisions(id uint, auth *model.Auth) ([]entity.PostRevision, error)
+	DiffPostRevisions(id uint, from, to int, auth *model.Auth) (*model.PostRevisionDiffResponse, error)
+	RestorePostRevision(id uint, revision int, auth *model.Auth) (*entity.Post, error)
 }
 
 type PostUseCaseImpl struct {
-	PostRepository     repository.PostRepository
-	CategoryRepository repository.CategoryRepository
-	validator          *validator.Validate
+	DB                     *gorm.DB
+	PostRepository         repository.PostRepository
+	PostRevisionRepository repository.PostRevisionRepository
+	CategoryRepository     repository.CategoryRepository
+	UserRepository         repository.UserRepository
+	Policy                 *policy.Engine
+	Audit                  AuditUseCase
+	Producer               *messaging.Producer[*model.PostEvent]
+	Log                    *zerolog.Logger
+	validator              *validator.Validate
 }
 
-func (s *PostUseCaseImpl) CreatePost(title, content string, authorID uint, categoryNames []string) (*entity.Post, error) {
+func (s *PostUseCaseImpl) CreatePost(title, content string, auth *model.Auth, categoryNames []string) (*entity.Post, error) {
+	if err := s.Policy.Authorize(auth, policy.ActionCreate, policy.Resource{Type: policy.ResourcePost}); err != nil {
+		return nil, err
+	}
+
+	if err := ensureEmailVerified(s.UserRepository, auth.ID); err != nil {
+		return nil, err
+	}
 
 	slug := utils.GenerateSlug(title)
 
@@ -56,18 +82,44 @@ func (s *PostUseCaseImpl) CreatePost(title, content string, authorID uint, categ
 		Title:      title,
 		Slug:       slug,
 		Content:    content,
-		AuthorID:   authorID,
+		AuthorID:   auth.ID,
+		Status:     entity.PostStatusDraft,
 		Categories: categories,
 	}
 
-	err = s.PostRepository.Create(post)
+	// post dan revisi pertamanya disimpan bersama agar setiap post selalu punya riwayat isi awal
+	tx := s.DB.Begin()
+	defer tx.Rollback()
+
+	err = s.PostRepository.Create(tx, post)
 	if err != nil {
 		return nil, errors.New("Gagal menyimpan postingan ke database: " + err.Error())
 	}
+	if err := s.PostRevisionRepository.Create(tx, newPostRevision(post, &auth.ID)); err != nil {
+		return nil, errors.New("Gagal menyimpan revisi postingan: " + err.Error())
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, errors.New("Gagal menyimpan postingan ke database: " + err.Error())
+	}
 	return post, nil
 }
 
-func (s *PostUseCaseImpl) DeletePost(id uint, authorID uint) error {
+// ensureEmailVerified menolak penulis yang belum memverifikasi email-nya
+func ensureEmailVerified(userRepository repository.UserRepository, userID uint) error {
+	user, err := userRepository.FindByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrNotFound("Pengguna")
+		}
+		return errors.New("Gagal memeriksa pengguna: " + err.Error())
+	}
+	if user.EmailVerifiedAt == nil {
+		return utils.ErrForbidden("Verifikasi email Anda terlebih dahulu")
+	}
+	return nil
+}
+
+func (s *PostUseCaseImpl) DeletePost(id uint, auth *model.Auth) error {
 	post, err := s.PostRepository.FindByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -76,30 +128,80 @@ func (s *PostUseCaseImpl) DeletePost(id uint, authorID uint) error {
 		return errors.New("gagal menemukan postingan untuk dihapus")
 	}
 
-	if post.AuthorID != authorID {
-		return utils.ErrForbidden("Anda tidak memiliki izin untuk menghapus postingan ini")
+	if err := s.Policy.Authorize(auth, policy.ActionDelete, postResource(post)); err != nil {
+		return err
 	}
 
 	err = s.PostRepository.Delete(id)
 	if err != nil {
 		return errors.New("gagal menghapus postingan dari database")
 	}
+
+	s.Audit.Record(auth, entity.AuditActionPostDelete, entity.AuditTargetPost, post.ID, model.AuditChanges{
+		"title":     {Before: post.Title},
+		"slug":      {Before: post.Slug},
+		"author_id": {Before: post.AuthorID},
+	})
 	return nil
 }
 
-func (s *PostUseCaseImpl) GetAllPosts(page, limit int) ([]entity.Post, error) {
-	offset := (page - 1) * limit
+// GetAllPosts menampilkan post yang sudah terbit. Admin melihat semua status dan boleh memfilter berdasarkan status.
+func (s *PostUseCaseImpl) GetAllPosts(request *model.ListPostRequest, auth *model.Auth) (*model.PageResponse[model.PostResponse], error) {
+	spec := repository.NewQuerySpec(request.Page, request.Limit)
+
+	isAdmin := auth != nil && auth.Role == string(entity.UserRoleAdmin)
+	switch {
+	case request.Status != "" && isAdmin:
+		spec.Scope(repository.PostsWithStatus(entity.PostStatus(request.Status)))
+	case request.Status != "" && request.Status != string(entity.PostStatusPublished):
+		return nil, utils.ErrForbidden("Filter status " + request.Status + " hanya tersedia untuk admin")
+	case !isAdmin:
+		spec.Scope(repository.PublishedPosts)
+	}
+
+	if request.Category != "" {
+		spec.Scope(repository.PostsInCategory(request.Category))
+	}
+	if request.Author != "" {
+		spec.Scope(repository.PostsByAuthor(request.Author))
+	}
+	if request.PublishedFrom != "" || request.PublishedTo != "" {
+		var from, to *time.Time
+		if request.PublishedFrom != "" {
+			parsed, _ := time.Parse(time.RFC3339, request.PublishedFrom)
+			from = &parsed
+		}
+		if request.PublishedTo != "" {
+			parsed, _ := time.Parse(time.RFC3339, request.PublishedTo)
+			to = &parsed
+		}
+		spec.Scope(repository.PostsPublishedBetween(from, to))
+	}
 
-	posts, err := s.PostRepository.FindAll(offset, limit)
+	sort, err := repository.ParseSort(request.Sort, repository.PostSortFields)
+	if err != nil {
+		return nil, utils.ErrValidation(err.Error())
+	}
+	if len(sort) == 0 {
+		// terbaru lebih dulu; draft tanpa published_at di /admin/posts diletakkan di akhir lewat NULLS LAST
+		sort, _ = repository.ParseSort("-published_at", repository.PostSortFields)
+	}
+	// id sebagai urutan terakhir agar post dengan nilai sort yang sama tidak berpindah halaman
+	spec.OrderBy(sort...).OrderBy(repository.SortField{Column: "posts.id", Desc: true})
+
+	posts, total, err := s.PostRepository.FindAll(spec)
 	if err != nil {
 		return nil, errors.New("gagal mengambil daftar postingan")
 	}
-	return posts, nil
+
+	return &model.PageResponse[model.PostResponse]{
+		Data: converter.PostsToResponses(posts),
+		PageMetadata: model.NewPageMetadata(request.Page, request.Limit, total),
+	}, nil
 }
 
 func (s *PostUseCaseImpl) GetPostBySlug(slug string) (*entity.Post, error) {
-	post, err := s.PostRepository.FindBySlug(slug)
-	fmt.Println("errore", err)
+	post, err := s.PostRepository.FindPublishedBySlug(slug)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, utils.ErrNotFound("postingan")
@@ -110,7 +212,7 @@ func (s *PostUseCaseImpl) GetPostBySlug(slug string) (*entity.Post, error) {
 }
 
 func (s *PostUseCaseImpl) GetPostByID(id uint) (*entity.Post, error) {
-	post, err := s.PostRepository.FindByID(id)
+	post, err := s.PostRepository.FindPublishedByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, utils.ErrNotFound("postingan")
@@ -120,7 +222,7 @@ func (s *PostUseCaseImpl) GetPostByID(id uint) (*entity.Post, error) {
 	return post, nil
 }
 
-func (s *PostUseCaseImpl) UpdatePost(id uint, title, content *string, publishedAt *time.Time, categoryNames *[]string, authorID uint) (*entity.Post, error) {
+func (s *PostUseCaseImpl) UpdatePost(id uint, title, content *string, categoryNames *[]string, auth *model.Auth) (*entity.Post, error) {
 	post, err := s.PostRepository.FindByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -129,12 +231,12 @@ func (s *PostUseCaseImpl) UpdatePost(id uint, title, content *string, publishedA
 		return nil, errors.New("gagal menemukan postingan untuk diperbarui")
 	}
 
-	// Otorisasi: Anda mungkin ingin memeriksa apakah `authorID` ini adalah pemilik post atau admin
-	// Ini bisa dilakukan di handler atau service tergantung kebutuhan.
-	// Contoh sederhana:
-	// if post.AuthorID != authorID {
-	// 	return utils.ErrForbidden("Anda tidak memiliki izin untuk memperbarui postingan ini")
-	// }
+	if err := s.Policy.Authorize(auth, policy.ActionUpdate, postResource(post)); err != nil {
+		return nil, err
+	}
+
+	// isi sebelum perubahan, dipakai sebagai revisi awal untuk post yang dibuat sebelum ada riwayat revisi
+	previous := newPostRevision(post, &post.AuthorID)
 
 	if title != nil {
 		newSlug := utils.GenerateSlug(*title)
@@ -154,9 +256,6 @@ func (s *PostUseCaseImpl) UpdatePost(id uint, title, content *string, publishedA
 	if content != nil {
 		post.Content = *content
 	}
-	if publishedAt != nil {
-		post.PublishedAt = publishedAt
-	}
 
 	// Update kategori
 	if categoryNames != nil {
@@ -175,17 +274,37 @@ func (s *PostUseCaseImpl) UpdatePost(id uint, title, content *string, publishedA
 		post.Categories = categories
 	}
 
-	err = s.PostRepository.Update(post)
+	tx := s.DB.Begin()
+	defer tx.Rollback()
+
+	err = s.PostRepository.Update(tx, post)
 	if err != nil {
 		return nil, errors.New("Gagal memperbarui postingan di database: " + err.Error())
 	}
+	if err := s.recordRevision(tx, post, previous, auth); err != nil {
+		return nil, errors.New("Gagal menyimpan revisi postingan: " + err.Error())
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, errors.New("Gagal memperbarui postingan di database: " + err.Error())
+	}
 	return post, nil
 }
 
-func NewPostUseCase(postRepo repository.PostRepository, categotyRepository repository.CategoryRepository, validator *validator.Validate) PostUseCase {
+func postResource(post *entity.Post) policy.Resource {
+	return policy.Resource{Type: policy.ResourcePost, OwnerID: post.AuthorID}
+}
+
+func NewPostUseCase(db *gorm.DB, postRepo repository.PostRepository, postRevisionRepository repository.PostRevisionRepository, categotyRepository repository.CategoryRepository, userRepository repository.UserRepository, policyEngine *policy.Engine, auditUseCase AuditUseCase, producer *messaging.Producer[*model.PostEvent], log *zerolog.Logger, validator *validator.Validate) PostUseCase {
 	return &PostUseCaseImpl{
-		PostRepository:     postRepo,
-		CategoryRepository: categotyRepository,
-		validator:          validator,
+		DB:                     db,
+		PostRepository:         postRepo,
+		PostRevisionRepository: postRevisionRepository,
+		CategoryRepository:     categotyRepository,
+		UserRepository:         userRepository,
+		Policy:                 policyEngine,
+		Audit:                  auditUseCase,
+		Producer:               producer,
+		Log:                    log,
+		validator:              validator,
 	}
 }
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model/converter"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/repository"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+type ProfileUseCase interface {
+	GetProfile(ctx context.Context, userID uint) (*model.ProfileResponse, error)
+	UpdateProfile(ctx context.Context, userID uint, request *model.UpdateProfileRequest) (*model.ProfileResponse, error)
+	GetAuthor(ctx context.Context, username string, page, size int) (*model.AuthorResponse, error)
+}
+
+type profileUseCaseImpl struct {
+	DB             *gorm.DB
+	Log            *zerolog.Logger
+	Validate       *validator.Validate
+	UserRepository repository.UserRepository
+	PostRepository repository.PostRepository
+}
+
+func NewProfileUseCase(db *gorm.DB, log *zerolog.Logger, validate *validator.Validate, userRepository repository.UserRepository, postRepository repository.PostRepository) ProfileUseCase {
+	return &profileUseCaseImpl{
+		DB:             db,
+		Log:            log,
+		Validate:       validate,
+		UserRepository: userRepository,
+		PostRepository: postRepository,
+	}
+}
+
+func (c *profileUseCaseImpl) GetProfile(ctx context.Context, userID uint) (*model.ProfileResponse, error) {
+	user, err := c.UserRepository.FindByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.ErrNotFound
+		}
+		c.Log.Error().Msgf("Failed to find user: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.UserToProfileResponse(user), nil
+}
+
+// UpdateProfile hanya mengubah field profil publik milik user yang sedang login
+func (c *profileUseCaseImpl) UpdateProfile(ctx context.Context, userID uint, request *model.UpdateProfileRequest) (*model.ProfileResponse, error) {
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warn().Msgf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	fields := make(map[string]interface{})
+	if request.DisplayName != nil {
+		fields["display_name"] = strings.TrimSpace(*request.DisplayName)
+	}
+	if request.Bio != nil {
+		fields["bio"] = strings.TrimSpace(*request.Bio)
+	}
+	if request.Website != nil {
+		fields["website"] = strings.TrimSpace(*request.Website)
+	}
+	if request.AvatarURL != nil {
+		fields["avatar_url"] = strings.TrimSpace(*request.AvatarURL)
+	}
+
+	if len(fields) > 0 {
+		if err := c.UserRepository.UpdateProfile(c.DB.WithContext(ctx), userID, fields); err != nil {
+			c.Log.Error().Msgf("Failed to update profile: %v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	return c.GetProfile(ctx, userID)
+}
+
+// GetAuthor menampilkan profil publik beserta post yang sudah terbit, tanpa email maupun data akun lainnya
+func (c *profileUseCaseImpl) GetAuthor(ctx context.Context, username string, page, size int) (*model.AuthorResponse, error) {
+	user, err := c.UserRepository.FindByUsername(username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.ErrNotFound
+		}
+		c.Log.Error().Msgf("Failed to find user by username: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	posts, total, err := c.PostRepository.FindPublishedByAuthorID(user.ID, (page-1)*size, size)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to find published posts: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	data := make([]model.AuthorPostResponse, len(posts))
+	for i := range posts {
+		data[i] = *converter.PostToAuthorPostResponse(&posts[i])
+	}
+
+	return &model.AuthorResponse{
+		ProfileResponse: *converter.UserToProfileResponse(user),
+		Posts: model.PageResponse[model.AuthorPostResponse]{
+			Data: data,
+			PageMetadata: model.NewPageMetadata(page, size, total),
+		},
+	}, nil
+}
@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model/converter"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/repository"
+	"github.com/gofiber/fiber/v2"
+	"github.com/knadh/koanf"
+	"github.com/rs/zerolog"
+)
+
+type SessionUseCase interface {
+	List(ctx context.Context, userID uint, currentSessionID string) ([]model.SessionResponse, error)
+	Revoke(ctx context.Context, userID uint, sessionID string) error
+	Touch(ctx context.Context, sessionID, ip string) error
+}
+
+type sessionUseCaseImpl struct {
+	Log                *zerolog.Logger
+	cfg                *koanf.Koanf
+	SessionRepository  repository.SessionRepository
+	TokenRepository    repository.TokenRepository
+	ThrottleRepository repository.ThrottleRepository
+}
+
+func NewSessionUseCase(log *zerolog.Logger, config *koanf.Koanf, sessionRepository repository.SessionRepository, tokenRepository repository.TokenRepository, throttleRepository repository.ThrottleRepository) SessionUseCase {
+	return &sessionUseCaseImpl{
+		Log:                log,
+		cfg:                config,
+		SessionRepository:  sessionRepository,
+		TokenRepository:    tokenRepository,
+		ThrottleRepository: throttleRepository,
+	}
+}
+
+func (c *sessionUseCaseImpl) List(ctx context.Context, userID uint, currentSessionID string) ([]model.SessionResponse, error) {
+	sessions, err := c.SessionRepository.FindByUserID(ctx, userID)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to list sessions: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.SessionResponse, len(sessions))
+	for i := range sessions {
+		responses[i] = *converter.SessionToResponse(&sessions[i], currentSessionID)
+	}
+	return responses, nil
+}
+
+// Revoke mencabut refresh token family dan semua access token milik sesi, lalu menghapus catatan sesinya
+func (c *sessionUseCaseImpl) Revoke(ctx context.Context, userID uint, sessionID string) error {
+	session, err := c.SessionRepository.Find(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, repository.ErrSessionNotFound) {
+			return fiber.ErrNotFound
+		}
+		c.Log.Error().Msgf("Failed to find session: %v", err)
+		return fiber.ErrInternalServerError
+	}
+	if session.UserID != userID {
+		return fiber.ErrNotFound
+	}
+
+	return revokeSession(ctx, c.Log, c.SessionRepository, c.TokenRepository, userID, sessionID)
+}
+
+// Touch memperbarui waktu aktivitas terakhir paling sering sekali per session.touch_interval.
+// ErrSessionNotFound dikembalikan jika sesi sudah tidak ada sehingga middleware dapat menolak token.
+func (c *sessionUseCaseImpl) Touch(ctx context.Context, sessionID, ip string) error {
+	interval := time.Duration(c.cfg.Int("session.touch_interval")) * time.Second
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	allowed, err := c.ThrottleRepository.Allow(ctx, "session_touch:"+sessionID, interval)
+	if err != nil || !allowed {
+		return err
+	}
+
+	return c.SessionRepository.Touch(ctx, sessionID, ip, time.Now())
+}
+
+// revokeSession dipakai bersama oleh pencabutan sesi dari daftar perangkat dan oleh Logout
+func revokeSession(ctx context.Context, log *zerolog.Logger, sessionRepository repository.SessionRepository, tokenRepository repository.TokenRepository, userID uint, sessionID string) error {
+	if err := tokenRepository.RevokeRefreshFamily(ctx, sessionID); err != nil {
+		log.Error().Msgf("Failed to revoke refresh token family: %v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := tokenRepository.RevokeSession(ctx, sessionID, time.Duration(JwtExpire)*time.Minute); err != nil {
+		log.Error().Msgf("Failed to revoke session access tokens: %v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := sessionRepository.Delete(ctx, userID, sessionID); err != nil {
+		log.Error().Msgf("Failed to delete session: %v", err)
+		return fiber.ErrInternalServerError
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// Impersonate menerbitkan access token berumur pendek atas nama userID untuk admin auth.
+// Token tidak memiliki refresh token dan tetap ditolak jika user yang ditiru melakukan "logout everywhere".
+func (c *userUseCaseImpl) Impersonate(ctx context.Context, auth *model.Auth, userID uint) (*model.ImpersonationResponse, error) {
+	if auth.ImpersonatorID != 0 {
+		return nil, utils.ErrForbidden("Tidak dapat memulai impersonation dari sesi impersonation")
+	}
+	if auth.ID == userID {
+		return nil, utils.ErrValidation("Tidak dapat meniru akun sendiri")
+	}
+
+	user, err := c.UserRepository.FindByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound("Pengguna")
+		}
+		c.Log.Error().Msgf("Failed to find user: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	// admin tidak boleh meniru admin lain agar impersonation tidak bisa dipakai untuk menyamarkan aksi admin
+	if user.Role == entity.UserRoleAdmin {
+		return nil, utils.ErrForbidden("Akun admin tidak dapat ditiru")
+	}
+
+	version, err := c.TokenRepository.GetTokenVersion(ctx, user.ID)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to get token version: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	expiration := c.configInt("impersonation.expiration", 15)
+	token, err := utils.GenerateImpersonationToken(user.ID, user.Role, version, auth.ID, c.JWTKeys, expiration)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to generate impersonation token: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	expiresAt := time.Now().Add(time.Duration(expiration) * time.Minute)
+
+	c.Log.Info().Uint("actor_id", auth.ID).Uint("user_id", user.ID).Str("request_id", auth.RequestID).Msg("Impersonation token issued")
+	c.Audit.Record(auth, entity.AuditActionUserImpersonate, entity.AuditTargetUser, user.ID, model.AuditChanges{
+		"expires_at": {After: expiresAt},
+	})
+
+	return &model.ImpersonationResponse{
+		Token:          token,
+		ExpiresAt:      expiresAt,
+		UserID:         user.ID,
+		Username:       user.Username,
+		ImpersonatorID: auth.ID,
+	}, nil
+}
@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/alexedwards/argon2id"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/policy"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ErrInvalidCredentials dipakai untuk email tidak terdaftar maupun password salah agar akun tidak dapat dienumerasi
+var ErrInvalidCredentials = fiber.NewError(fiber.StatusUnauthorized, "Email atau password salah")
+
+// compareDummyPassword menyamakan waktu respons untuk email yang tidak terdaftar dengan waktu verifikasi argon2.
+// Hash dummy memakai parameter yang dikonfigurasi agar biayanya sama dengan hash user sungguhan.
+func (c *userUseCaseImpl) compareDummyPassword(password string) {
+	c.dummyPasswordHashOnce.Do(func() {
+		c.dummyPasswordHash, _ = argon2id.CreateHash("dummy-password", c.HashParams)
+	})
+	_, _ = argon2id.ComparePasswordAndHash(password, c.dummyPasswordHash)
+}
+
+func accountLoginSubject(email string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipLoginSubject(ip string) string {
+	return "ip:" + ip
+}
+
+func (c *userUseCaseImpl) configInt(key string, fallback int) int {
+	if value := c.cfg.Int(key); value > 0 {
+		return value
+	}
+	return fallback
+}
+
+// checkLoginBlocked menolak percobaan login selama akun atau IP masih dalam jeda progresif atau lockout
+func (c *userUseCaseImpl) checkLoginBlocked(ctx context.Context, request *model.LoginUserRequest) error {
+	retryAfter, err := c.LoginAttemptRepository.BlockedFor(ctx, accountLoginSubject(request.Email), ipLoginSubject(request.IP))
+	if err != nil {
+		c.Log.Error().Msgf("Failed to check login block: %v", err)
+		return fiber.ErrInternalServerError
+	}
+	if retryAfter > 0 {
+		return utils.ErrTooManyRequests{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// loginFailed mencatat kegagalan untuk akun dan IP. Setelah login_protection.delay_after kegagalan,
+// percobaan berikutnya harus menunggu jeda yang berlipat dua, dan setelah mencapai batas subjek dikunci.
+func (c *userUseCaseImpl) loginFailed(ctx context.Context, request *model.LoginUserRequest) error {
+	window := time.Duration(c.configInt("login_protection.window", 15)) * time.Minute
+	lockout := time.Duration(c.configInt("login_protection.lockout", 15)) * time.Minute
+
+	account := accountLoginSubject(request.Email)
+	failures, err := c.LoginAttemptRepository.RegisterFailure(ctx, account, window)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to register login failure: %v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if failures >= int64(c.configInt("login_protection.account_max_attempts", 5)) {
+		if err := c.LoginAttemptRepository.Lock(ctx, account, lockout); err != nil {
+			c.Log.Error().Msgf("Failed to lock account login: %v", err)
+			return fiber.ErrInternalServerError
+		}
+		c.Log.Warn().Msgf("Login locked for %s after %d failed attempts from %s, lockout %s", account, failures, request.IP, lockout)
+	} else if delay := c.loginDelay(failures); delay > 0 {
+		if err := c.LoginAttemptRepository.Delay(ctx, account, delay); err != nil {
+			c.Log.Error().Msgf("Failed to delay account login: %v", err)
+			return fiber.ErrInternalServerError
+		}
+	}
+
+	if request.IP != "" {
+		ip := ipLoginSubject(request.IP)
+		failures, err := c.LoginAttemptRepository.RegisterFailure(ctx, ip, window)
+		if err != nil {
+			c.Log.Error().Msgf("Failed to register login failure: %v", err)
+			return fiber.ErrInternalServerError
+		}
+
+		if failures >= int64(c.configInt("login_protection.ip_max_attempts", 50)) {
+			if err := c.LoginAttemptRepository.Lock(ctx, ip, lockout); err != nil {
+				c.Log.Error().Msgf("Failed to lock ip login: %v", err)
+				return fiber.ErrInternalServerError
+			}
+			c.Log.Warn().Msgf("Login locked for %s after %d failed attempts, lockout %s", ip, failures, lockout)
+		}
+	}
+
+	return ErrInvalidCredentials
+}
+
+func (c *userUseCaseImpl) loginDelay(failures int64) time.Duration {
+	delayAfter := int64(c.configInt("login_protection.delay_after", 3))
+	if failures < delayAfter {
+		return 0
+	}
+
+	delay := time.Duration(c.configInt("login_protection.delay_base", 1)) * time.Second
+	maxDelay := time.Duration(c.configInt("login_protection.delay_max", 30)) * time.Second
+	for i := delayAfter; i < failures && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
+
+// loginSucceeded hanya mereset counter akun; counter IP tetap berjalan agar satu akun valid
+// tidak dapat dipakai untuk menghapus jejak tebakan terhadap akun lain.
+func (c *userUseCaseImpl) loginSucceeded(ctx context.Context, request *model.LoginUserRequest) {
+	account := accountLoginSubject(request.Email)
+	wasLocked, err := c.LoginAttemptRepository.Reset(ctx, account)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to reset login failures: %v", err)
+		return
+	}
+	if wasLocked {
+		c.Log.Info().Msgf("Login unlocked for %s after successful login from %s", account, request.IP)
+	}
+}
+
+// UnlockLogin membuka kunci login sebuah akun sebelum lockout berakhir dengan sendirinya
+func (c *userUseCaseImpl) UnlockLogin(ctx context.Context, id uint, auth *model.Auth) error {
+	if err := c.Policy.Authorize(auth, policy.ActionUnlock, policy.Resource{Type: policy.ResourceUser, OwnerID: id}); err != nil {
+		return err
+	}
+
+	user, err := c.UserRepository.FindByID(id)
+	if err != nil {
+		return utils.ErrNotFound("Pengguna")
+	}
+
+	account := accountLoginSubject(user.Email)
+	if _, err := c.LoginAttemptRepository.Reset(ctx, account); err != nil {
+		c.Log.Error().Msgf("Failed to reset login failures: %v", err)
+		return fiber.ErrInternalServerError
+	}
+	c.Log.Info().Msgf("Login unlocked for %s by user %d", account, auth.ID)
+
+	return nil
+}
@@ -0,0 +1,239 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/gateway/oidc"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/repository"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// ErrInvalidOIDCLogin dipakai untuk state yang tidak dikenal, code yang ditolak provider, maupun id_token yang tidak valid
+var ErrInvalidOIDCLogin = fiber.NewError(fiber.StatusUnauthorized, "Login dengan provider gagal atau sudah kedaluwarsa")
+
+var usernameInvalidChars = regexp.MustCompile(`[^a-z0-9_.-]+`)
+
+// OIDCAuthorizationURL menyiapkan state, nonce, dan PKCE code verifier lalu mengembalikan URL login provider
+func (c *userUseCaseImpl) OIDCAuthorizationURL(ctx context.Context, providerName string) (string, error) {
+	provider, ok := c.OIDCProviders[providerName]
+	if !ok {
+		return "", utils.ErrNotFound("Provider OIDC")
+	}
+
+	values := make([]string, 3)
+	for i := range values {
+		value, err := utils.GenerateRandomToken(32)
+		if err != nil {
+			c.Log.Error().Msgf("Failed to generate OIDC state: %v", err)
+			return "", fiber.ErrInternalServerError
+		}
+		values[i] = value
+	}
+	state, nonce, codeVerifier := values[0], values[1], values[2]
+
+	ttl := time.Duration(c.configInt("oidc.state_expiration", 10)) * time.Minute
+	err := c.OIDCStateRepository.Save(ctx, state, &entity.OIDCState{
+		Provider:     provider.Name(),
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+	}, ttl)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to save OIDC state: %v", err)
+		return "", fiber.ErrInternalServerError
+	}
+
+	authURL, err := provider.AuthCodeURL(ctx, state, nonce, codeVerifier)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to build OIDC authorization url for %s: %v", provider.Name(), err)
+		return "", fiber.ErrBadGateway
+	}
+	return authURL, nil
+}
+
+// OIDCCallback menukar authorization code, menautkan identitas ke user lokal, lalu menerbitkan token seperti Login biasa
+func (c *userUseCaseImpl) OIDCCallback(ctx context.Context, request *model.OIDCCallbackRequest) (*model.UserResponse, error) {
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warn().Msgf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	state, err := c.OIDCStateRepository.Consume(ctx, request.State)
+	if err != nil {
+		if errors.Is(err, repository.ErrOIDCStateNotFound) {
+			c.Log.Warn().Msg("OIDC state not found or expired")
+			return nil, ErrInvalidOIDCLogin
+		}
+		c.Log.Error().Msgf("Failed to consume OIDC state: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	provider, ok := c.OIDCProviders[request.Provider]
+	if !ok || state.Provider != request.Provider {
+		c.Log.Warn().Msgf("OIDC state issued for %s used on %s callback", state.Provider, request.Provider)
+		return nil, ErrInvalidOIDCLogin
+	}
+
+	claims, err := provider.Exchange(ctx, request.Code, state.CodeVerifier, state.Nonce)
+	if err != nil {
+		c.Log.Warn().Msgf("OIDC exchange with %s failed: %v", provider.Name(), err)
+		return nil, ErrInvalidOIDCLogin
+	}
+
+	user, err := c.resolveOIDCUser(ctx, provider.Name(), claims)
+	if err != nil {
+		return nil, err
+	}
+
+	challenge, err := c.startTwoFactorChallenge(ctx, user)
+	if err != nil || challenge != nil {
+		return challenge, err
+	}
+
+	return c.startSession(ctx, user, &request.ClientInfo)
+}
+
+// resolveOIDCUser mencari user lewat identitas yang sudah tertaut, lalu lewat email terverifikasi.
+// Akun lokal yang emailnya belum diverifikasi tidak ditautkan, karena pendaftar akun tersebut belum
+// tentu pemilik email dan masih mengetahui password-nya.
+func (c *userUseCaseImpl) resolveOIDCUser(ctx context.Context, providerName string, claims *oidc.Claims) (*entity.User, error) {
+	identity, err := c.UserIdentityRepository.FindByProviderSubject(providerName, claims.Subject)
+	if err == nil {
+		user, err := c.UserRepository.FindByID(identity.UserID)
+		if err != nil {
+			c.Log.Error().Msgf("Failed to find user of identity %d: %v", identity.ID, err)
+			return nil, fiber.ErrInternalServerError
+		}
+		return user, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.Log.Error().Msgf("Failed to find user identity: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, utils.ErrForbidden("Email dari provider belum terverifikasi")
+	}
+
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	user, err := c.UserRepository.FindByEmail(claims.Email)
+	switch {
+	case err == nil:
+		if user.EmailVerifiedAt == nil {
+			return nil, utils.ErrForbidden("Verifikasi email akun Anda terlebih dahulu sebelum login dengan " + providerName)
+		}
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		// login OIDC tidak membawa kode undangan, jadi akun baru hanya dibuat pada mode registrasi open
+		mode, err := c.registrationMode()
+		if err != nil {
+			return nil, err
+		}
+		if mode != entity.RegistrationModeOpen {
+			return nil, utils.ErrForbidden("Registrasi akun baru lewat " + providerName + " tidak tersedia")
+		}
+		if user, err = c.createOIDCUser(tx, claims); err != nil {
+			return nil, err
+		}
+	default:
+		c.Log.Error().Msgf("Failed to find user by email: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	err = c.UserIdentityRepository.Create(tx, &entity.UserIdentity{
+		UserID:   user.ID,
+		Provider: providerName,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	})
+	if err != nil {
+		c.Log.Error().Msgf("Failed to create user identity: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Error().Msgf("Failed to commit transaction: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	c.Log.Info().Msgf("Linked %s identity to user %d", providerName, user.ID)
+	return user, nil
+}
+
+// createOIDCUser membuat user dengan password acak yang tidak diketahui siapa pun; user dapat memasang
+// password sendiri lewat alur lupa password jika ingin login tanpa provider.
+func (c *userUseCaseImpl) createOIDCUser(tx *gorm.DB, claims *oidc.Claims) (*entity.User, error) {
+	username, err := c.availableUsername(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	randomPassword, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to generate password: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	passwordHash, err := c.hashPassword(randomPassword)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to hash password: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	now := time.Now()
+	user := &entity.User{
+		Email:           claims.Email,
+		PasswordHash:    passwordHash,
+		Username:        username,
+		Role:            entity.UserRoleReader,
+		EmailVerifiedAt: &now,
+	}
+	if err := c.UserRepository.Create(tx, user); err != nil {
+		c.Log.Error().Msgf("Failed to create user: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	return user, nil
+}
+
+func (c *userUseCaseImpl) availableUsername(claims *oidc.Claims) (string, error) {
+	base := claims.PreferredUsername
+	if base == "" {
+		base, _, _ = strings.Cut(claims.Email, "@")
+	}
+	base = usernameInvalidChars.ReplaceAllString(strings.ToLower(base), "")
+	if len(base) > 40 {
+		base = base[:40]
+	}
+	if len(base) < 3 {
+		base = "user"
+	}
+
+	candidate := base
+	for i := 0; i < 5; i++ {
+		total, err := c.UserRepository.CountByUsername(candidate)
+		if err != nil {
+			c.Log.Error().Msgf("Failed to count user by username: %v", err)
+			return "", fiber.ErrInternalServerError
+		}
+		if total == 0 {
+			return candidate, nil
+		}
+
+		suffix, err := utils.GenerateRandomToken(3)
+		if err != nil {
+			c.Log.Error().Msgf("Failed to generate username suffix: %v", err)
+			return "", fiber.ErrInternalServerError
+		}
+		candidate = base + "-" + strings.ToLower(usernameInvalidChars.ReplaceAllString(suffix, ""))
+	}
+
+	c.Log.Error().Msgf("Failed to find available username for %s", base)
+	return "", fiber.ErrConflict
+}
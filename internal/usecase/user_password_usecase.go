@@ -0,0 +1,290 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/alexedwards/argon2id"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/knadh/koanf"
+	"gorm.io/gorm"
+)
+
+// ForgotPassword selalu mengembalikan respons yang sama, baik email terdaftar, tidak terdaftar, maupun sedang di-throttle
+func (c *userUseCaseImpl) ForgotPassword(ctx context.Context, request *model.ForgotPasswordRequest) error {
+	err := c.Validate.Struct(request)
+	if err != nil {
+		c.Log.Warn().Msgf("Invalid request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	interval := time.Duration(c.cfg.Int("password_reset.resend_interval")) * time.Second
+	if interval == 0 {
+		interval = time.Minute
+	}
+
+	allowed, err := c.ThrottleRepository.Allow(ctx, "password_reset:"+strings.ToLower(request.Email), interval)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to check password reset throttle: %v", err)
+		return fiber.ErrInternalServerError
+	}
+	if !allowed {
+		return nil
+	}
+
+	// pencarian user, pembuatan token, dan pengiriman email berjalan di latar belakang agar waktu respons
+	// tidak membedakan email yang terdaftar dari yang tidak
+	go c.sendPasswordReset(request.Email)
+	return nil
+}
+
+// sendPasswordReset membuat token reset dan mengirimkannya; kegagalan hanya dicatat karena respons sudah dikirim
+func (c *userUseCaseImpl) sendPasswordReset(email string) {
+	user, err := c.UserRepository.FindByEmail(email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return
+		}
+		c.Log.Error().Msgf("Failed to find user by email: %v", err)
+		return
+	}
+
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to generate password reset token: %v", err)
+		return
+	}
+
+	expiration := c.cfg.Int("password_reset.expiration")
+	if expiration == 0 {
+		expiration = 30
+	}
+
+	tx := c.DB.Begin()
+	defer tx.Rollback()
+
+	if err := c.PasswordResetRepository.InvalidateByUserID(tx, user.ID); err != nil {
+		c.Log.Warn().Msgf("Failed to invalidate password reset tokens : %v", err)
+		return
+	}
+
+	err = c.PasswordResetRepository.Create(tx, &entity.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().Add(time.Duration(expiration) * time.Minute),
+	})
+	if err != nil {
+		c.Log.Warn().Msgf("Failed to create password reset token : %v", err)
+		return
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warn().Msgf("Failed to commit transaction : %v", err)
+		return
+	}
+
+	resetURL := c.cfg.String("password_reset.url")
+	if resetURL == "" {
+		resetURL = strings.TrimRight(c.cfg.String("app.url"), "/") + "/reset-password"
+	}
+	link := resetURL + "?token=" + url.QueryEscape(token)
+	body := "Halo " + user.Username + ",\n\nGunakan tautan berikut untuk mengatur ulang password Anda:\n" + link +
+		"\n\nTautan ini hanya berlaku satu kali. Abaikan email ini jika Anda tidak memintanya.\n"
+
+	if err := c.Mailer.Send(user.Email, "Atur ulang password", body); err != nil {
+		c.Log.Error().Msgf("Failed to send password reset email: %v", err)
+	}
+}
+
+// ResetPassword mengganti password lalu mencabut seluruh sesi user yang masih aktif
+func (c *userUseCaseImpl) ResetPassword(ctx context.Context, request *model.ResetPasswordRequest) error {
+	err := c.Validate.Struct(request)
+	if err != nil {
+		c.Log.Warn().Msgf("Invalid request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	token, err := c.PasswordResetRepository.FindValidByHash(utils.HashToken(request.Token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Log.Warn().Msg("Password reset token not found, used or expired")
+			return fiber.ErrBadRequest
+		}
+		c.Log.Error().Msgf("Failed to find password reset token: %v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	user, err := c.UserRepository.FindByID(token.UserID)
+	if err != nil {
+		c.Log.Warn().Msgf("User for password reset token not found: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	if err := c.checkPassword(request.Password, user.Username, user.Email); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	passwordHash, err := c.hashPassword(request.Password)
+	if err != nil {
+		c.Log.Warn().Msgf("Failed to hash password : %v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	used, err := c.PasswordResetRepository.MarkUsed(tx, token.ID)
+	if err != nil {
+		c.Log.Warn().Msgf("Failed to mark password reset token as used : %v", err)
+		return fiber.ErrInternalServerError
+	}
+	if !used {
+		c.Log.Warn().Msg("Password reset token already used")
+		return fiber.ErrBadRequest
+	}
+
+	user.PasswordHash = passwordHash
+	if err := c.UserRepository.Update(tx, user); err != nil {
+		c.Log.Warn().Msgf("Failed to update password : %v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warn().Msgf("Failed to commit transaction : %v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	return c.LogoutAll(ctx, user.ID)
+}
+
+func (c *userUseCaseImpl) hashPassword(password string) (string, error) {
+	return argon2id.CreateHash(password, c.HashParams)
+}
+
+// rehashPasswordIfOutdated dipanggil setelah password terbukti benar saat login, satu-satunya saat plaintext tersedia.
+// Kegagalan hanya dicatat karena login tetap sah dengan hash lama.
+func (c *userUseCaseImpl) rehashPasswordIfOutdated(user *entity.User, password string) {
+	params, _, _, err := argon2id.DecodeHash(user.PasswordHash)
+	if err != nil {
+		c.Log.Warn().Msgf("Failed to decode password hash of user %d: %v", user.ID, err)
+		return
+	}
+
+	// definisi "terbaru" harus sama dengan PasswordHashReport agar akun yang dilaporkan outdated memang di-rehash
+	if hashParamsString(params) == hashParamsString(c.HashParams) {
+		return
+	}
+
+	passwordHash, err := c.hashPassword(password)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to rehash password: %v", err)
+		return
+	}
+
+	// hanya ganti jika hash belum berubah, supaya reset password yang terjadi bersamaan tidak tertimpa
+	updated, err := c.UserRepository.UpdatePasswordHash(c.DB, user.ID, user.PasswordHash, passwordHash)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to store rehashed password: %v", err)
+		return
+	}
+	if updated {
+		c.Log.Info().Msgf("Password hash of user %d upgraded to %s", user.ID, hashParamsString(c.HashParams))
+		user.PasswordHash = passwordHash
+	}
+}
+
+// PasswordHashReport menghitung jumlah akun per parameter argon2id agar admin dapat memantau migrasi parameter
+func (c *userUseCaseImpl) PasswordHashReport() (*model.PasswordHashReportResponse, error) {
+	counts, err := c.UserRepository.CountByPasswordHashParams()
+	if err != nil {
+		c.Log.Error().Msgf("Failed to count password hash params: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	current := hashParamsString(c.HashParams)
+	response := &model.PasswordHashReportResponse{
+		Current: current,
+		Params:  make([]model.PasswordHashParamsCount, 0, len(counts)),
+	}
+	for _, count := range counts {
+		isCurrent := count.Params == current
+		response.Total += count.Total
+		if !isCurrent {
+			response.Outdated += count.Total
+		}
+		response.Params = append(response.Params, model.PasswordHashParamsCount{
+			Params:  count.Params,
+			Count:   count.Total,
+			Current: isCurrent,
+		})
+	}
+	return response, nil
+}
+
+// hashParamsString menghasilkan format yang sama dengan UserRepository.CountByPasswordHashParams: bagian keempat
+// hash PHC ditambah panjang salt dan key dalam byte, misalnya "m=65536,t=3,p=2,s=16,k=32"
+func hashParamsString(params *argon2id.Params) string {
+	return fmt.Sprintf("m=%d,t=%d,p=%d,s=%d,k=%d", params.Memory, params.Iterations, params.Parallelism, params.SaltLength, params.KeyLength)
+}
+
+// newHashParams membaca password_hash.*; nilai yang tidak diatur memakai argon2id.DefaultParams
+func newHashParams(config *koanf.Koanf) *argon2id.Params {
+	params := *argon2id.DefaultParams
+	if v := config.Int("password_hash.memory"); v > 0 {
+		params.Memory = uint32(v)
+	}
+	if v := config.Int("password_hash.iterations"); v > 0 {
+		params.Iterations = uint32(v)
+	}
+	if v := config.Int("password_hash.parallelism"); v > 0 {
+		params.Parallelism = uint8(v)
+	}
+	if v := config.Int("password_hash.salt_length"); v > 0 {
+		params.SaltLength = uint32(v)
+	}
+	if v := config.Int("password_hash.key_length"); v > 0 {
+		params.KeyLength = uint32(v)
+	}
+	return &params
+}
+
+// checkPassword menerapkan password policy lalu memeriksa daftar password bocor. Jika daftar tidak dapat
+// dibaca, password tetap diterima agar registrasi dan reset tidak ikut gagal.
+func (c *userUseCaseImpl) checkPassword(password string, identities ...string) error {
+	if err := c.PasswordPolicy.Check(password, identities...); err != nil {
+		return err
+	}
+
+	breached, err := c.BreachChecker.IsBreached(password)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to check breached password list: %v", err)
+		return nil
+	}
+	if breached {
+		return utils.ErrValidation("Password ini pernah muncul dalam kebocoran data, gunakan password lain")
+	}
+	return nil
+}
+
+func newPasswordPolicy(config *koanf.Koanf) utils.PasswordPolicy {
+	minLength := config.Int("password_policy.min_length")
+	if minLength <= 0 {
+		minLength = 8
+	}
+
+	return utils.PasswordPolicy{
+		MinLength:      minLength,
+		RequireUpper:   config.Bool("password_policy.require_upper"),
+		RequireLower:   config.Bool("password_policy.require_lower"),
+		RequireDigit:   config.Bool("password_policy.require_digit"),
+		RequireSymbol:  config.Bool("password_policy.require_symbol"),
+		RejectIdentity: config.Bool("password_policy.reject_identity"),
+	}
+}
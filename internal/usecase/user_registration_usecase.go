@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model/converter"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// invitationCodePrefix memudahkan kode undangan dikenali saat ditempel di formulir atau pesan
+const invitationCodePrefix = "inv_"
+
+var (
+	ErrRegistrationClosed    = fiber.NewError(fiber.StatusForbidden, "Registrasi akun baru sedang ditutup")
+	ErrInvitationRequired    = fiber.NewError(fiber.StatusForbidden, "Registrasi membutuhkan kode undangan")
+	ErrInvalidInvitationCode = fiber.NewError(fiber.StatusBadRequest, "Kode undangan tidak valid atau sudah tidak berlaku")
+)
+
+// registrationMode membaca pengaturan dari app_settings terlebih dahulu agar admin dapat mengubahnya tanpa deploy,
+// lalu jatuh ke registration.mode di config
+func (c *userUseCaseImpl) registrationMode() (string, error) {
+	value, found, err := c.SettingRepository.Get(entity.SettingRegistrationMode)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to get registration mode: %v", err)
+		return "", fiber.ErrInternalServerError
+	}
+	if found && entity.IsValidRegistrationMode(value) {
+		return value, nil
+	}
+
+	if mode := c.cfg.String("registration.mode"); entity.IsValidRegistrationMode(mode) {
+		return mode, nil
+	}
+	return entity.RegistrationModeOpen, nil
+}
+
+// applyInvitation dijalankan di dalam transaksi Create sehingga pemakaian kode ikut dibatalkan jika registrasi gagal.
+// Pada mode open, kode tetap boleh dipakai untuk memberikan role selain reader.
+func (c *userUseCaseImpl) applyInvitation(tx *gorm.DB, code string, user *entity.User) error {
+	mode, err := c.registrationMode()
+	if err != nil {
+		return err
+	}
+
+	switch mode {
+	case entity.RegistrationModeClosed:
+		return ErrRegistrationClosed
+	case entity.RegistrationModeInviteOnly:
+		if code == "" {
+			return ErrInvitationRequired
+		}
+	}
+	if code == "" {
+		return nil
+	}
+
+	invitation, ok, err := c.InvitationRepository.Consume(tx, utils.HashToken(strings.TrimSpace(code)))
+	if err != nil {
+		c.Log.Error().Msgf("Failed to consume invitation: %v", err)
+		return fiber.ErrInternalServerError
+	}
+	if !ok {
+		c.Log.Warn().Msg("Registration with invalid invitation code")
+		return ErrInvalidInvitationCode
+	}
+
+	user.Role = invitation.Role
+	return nil
+}
+
+func (c *userUseCaseImpl) GetRegistrationMode() (*model.RegistrationModeResponse, error) {
+	mode, err := c.registrationMode()
+	if err != nil {
+		return nil, err
+	}
+	return &model.RegistrationModeResponse{Mode: mode}, nil
+}
+
+func (c *userUseCaseImpl) SetRegistrationMode(auth *model.Auth, request *model.RegistrationModeRequest) (*model.RegistrationModeResponse, error) {
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warn().Msgf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	before, err := c.registrationMode()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.SettingRepository.Set(entity.SettingRegistrationMode, request.Mode); err != nil {
+		c.Log.Error().Msgf("Failed to save registration mode: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	changes := model.AuditChanges{}
+	changes.Set("mode", before, request.Mode)
+	c.Audit.Record(auth, entity.AuditActionRegistrationMode, entity.AuditTargetSetting, 0, changes)
+	return &model.RegistrationModeResponse{Mode: request.Mode}, nil
+}
+
+// CreateInvitation mengembalikan kode lengkap satu kali saja; yang tersimpan hanya hash-nya
+func (c *userUseCaseImpl) CreateInvitation(ctx context.Context, auth *model.Auth, request *model.CreateInvitationRequest) (*model.InvitationResponse, error) {
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warn().Msgf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	expiresAt := time.Now().Add(time.Duration(c.configInt("registration.invitation_expiration", 7*24)) * time.Hour)
+	if request.ExpiresAt != nil {
+		if !request.ExpiresAt.After(time.Now()) {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "expires_at harus di masa depan")
+		}
+		expiresAt = *request.ExpiresAt
+	}
+
+	secret, err := utils.GenerateRandomToken(18)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to generate invitation code: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	code := invitationCodePrefix + secret
+
+	invitation := &entity.Invitation{
+		CodeHash:  utils.HashToken(code),
+		Role:      entity.UserRole(request.Role),
+		MaxUses:   request.MaxUses,
+		ExpiresAt: expiresAt,
+		CreatedBy: &auth.ID,
+	}
+	if err := c.InvitationRepository.Create(invitation); err != nil {
+		c.Log.Error().Msgf("Failed to create invitation: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	c.Audit.Record(auth, entity.AuditActionInvitationCreate, entity.AuditTargetInvitation, invitation.ID, model.AuditChanges{
+		"role":       {After: request.Role},
+		"max_uses":   {After: request.MaxUses},
+		"expires_at": {After: expiresAt},
+	})
+
+	response := converter.InvitationToResponse(invitation)
+	response.Code = code
+	return response, nil
+}
+
+func (c *userUseCaseImpl) ListInvitations() ([]model.InvitationResponse, error) {
+	invitations, err := c.InvitationRepository.FindAll()
+	if err != nil {
+		c.Log.Error().Msgf("Failed to list invitations: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.InvitationResponse, len(invitations))
+	for i := range invitations {
+		responses[i] = *converter.InvitationToResponse(&invitations[i])
+	}
+	return responses, nil
+}
+
+func (c *userUseCaseImpl) RevokeInvitation(auth *model.Auth, id uint) error {
+	revoked, err := c.InvitationRepository.Revoke(id)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to revoke invitation: %v", err)
+		return fiber.ErrInternalServerError
+	}
+	if !revoked {
+		return fiber.ErrNotFound
+	}
+
+	c.Audit.Record(auth, entity.AuditActionInvitationRevoke, entity.AuditTargetInvitation, id, nil)
+	return nil
+}
@@ -0,0 +1,435 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/repository"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+const recoveryCodeCount = 10
+
+// ErrInvalidTwoFactorCode dipakai untuk kode TOTP/pemulihan yang salah maupun challenge yang tidak dikenal
+var ErrInvalidTwoFactorCode = fiber.NewError(fiber.StatusUnauthorized, "Kode 2FA tidak valid atau challenge sudah kedaluwarsa")
+
+// startTwoFactorChallenge mengembalikan nil jika user boleh langsung menerima token.
+// Jika 2FA aktif, atau role user mewajibkan 2FA tetapi belum didaftarkan, yang dikembalikan adalah challenge token.
+func (c *userUseCaseImpl) startTwoFactorChallenge(ctx context.Context, user *entity.User) (*model.UserResponse, error) {
+	purpose := entity.TwoFactorChallengeLogin
+	if !user.TwoFactorEnabled() {
+		required, err := c.isTwoFactorRequired(user.Role)
+		if err != nil {
+			return nil, err
+		}
+		if !required {
+			return nil, nil
+		}
+		purpose = entity.TwoFactorChallengeSetup
+	}
+
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to generate 2FA challenge token: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	ttl := time.Duration(c.configInt("two_factor.challenge_expiration", 5)) * time.Minute
+	err = c.TwoFactorChallengeRepository.Create(ctx, token, &entity.TwoFactorChallenge{UserID: user.ID, Purpose: purpose}, ttl)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to store 2FA challenge: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return &model.UserResponse{
+		ID:                     user.ID,
+		Username:               user.Username,
+		TwoFactorRequired:      purpose == entity.TwoFactorChallengeLogin,
+		TwoFactorSetupRequired: purpose == entity.TwoFactorChallengeSetup,
+		ChallengeToken:         token,
+	}, nil
+}
+
+// VerifyTwoFactor menyelesaikan login yang tertahan di langkah 2FA. Untuk challenge "setup",
+// kode TOTP sekaligus mengonfirmasi pendaftaran authenticator dan kode pemulihan ikut dikembalikan.
+func (c *userUseCaseImpl) VerifyTwoFactor(ctx context.Context, request *model.TwoFactorVerifyRequest) (*model.UserResponse, error) {
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warn().Msgf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	challenge, user, err := c.findTwoFactorChallenge(ctx, request.ChallengeToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// langkah 2FA berbagi counter dan lockout dengan langkah password untuk akun dan IP yang sama
+	login := &model.LoginUserRequest{Email: user.Email, ClientInfo: request.ClientInfo}
+	if err := c.checkLoginBlocked(ctx, login); err != nil {
+		return nil, err
+	}
+
+	var recoveryCodes []string
+	switch challenge.Purpose {
+	case entity.TwoFactorChallengeSetup:
+		if request.Code == "" || user.TOTPSecret == nil {
+			return nil, c.twoFactorChallengeFailed(ctx, request.ChallengeToken, login)
+		}
+		valid, err := c.verifyTOTP(ctx, user, request.Code)
+		if err != nil {
+			return nil, err
+		}
+		if !valid {
+			return nil, c.twoFactorChallengeFailed(ctx, request.ChallengeToken, login)
+		}
+		if recoveryCodes, err = c.activateTwoFactor(ctx, user); err != nil {
+			return nil, err
+		}
+	default:
+		valid, err := c.verifySecondFactor(ctx, user, request.Code, request.RecoveryCode)
+		if err != nil {
+			return nil, err
+		}
+		if !valid {
+			return nil, c.twoFactorChallengeFailed(ctx, request.ChallengeToken, login)
+		}
+	}
+
+	deleted, err := c.TwoFactorChallengeRepository.Delete(ctx, request.ChallengeToken)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to delete 2FA challenge: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if !deleted {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	c.loginSucceeded(ctx, login)
+
+	response, err := c.startSession(ctx, user, &request.ClientInfo)
+	if err != nil {
+		return nil, err
+	}
+	response.RecoveryCodes = recoveryCodes
+	return response, nil
+}
+
+// SetupTwoFactor membuat secret baru untuk user yang diwajibkan 2FA saat login, sebelum ia memiliki access token
+func (c *userUseCaseImpl) SetupTwoFactor(ctx context.Context, request *model.TwoFactorSetupRequest) (*model.TwoFactorEnrollResponse, error) {
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warn().Msgf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	challenge, user, err := c.findTwoFactorChallenge(ctx, request.ChallengeToken)
+	if err != nil {
+		return nil, err
+	}
+	if challenge.Purpose != entity.TwoFactorChallengeSetup {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	return c.enrollTwoFactor(ctx, user)
+}
+
+// EnrollTwoFactor membuat secret baru yang belum aktif sampai dikonfirmasi lewat EnableTwoFactor
+func (c *userUseCaseImpl) EnrollTwoFactor(ctx context.Context, userID uint) (*model.TwoFactorEnrollResponse, error) {
+	user, err := c.UserRepository.FindByID(userID)
+	if err != nil {
+		return nil, utils.ErrNotFound("Pengguna")
+	}
+	if user.TwoFactorEnabled() {
+		return nil, utils.ErrValidation("2FA sudah aktif, nonaktifkan terlebih dahulu untuk mendaftarkan authenticator baru")
+	}
+
+	return c.enrollTwoFactor(ctx, user)
+}
+
+func (c *userUseCaseImpl) EnableTwoFactor(ctx context.Context, userID uint, request *model.TwoFactorEnableRequest) (*model.TwoFactorRecoveryCodesResponse, error) {
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warn().Msgf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	user, err := c.UserRepository.FindByID(userID)
+	if err != nil {
+		return nil, utils.ErrNotFound("Pengguna")
+	}
+	if user.TwoFactorEnabled() {
+		return nil, utils.ErrValidation("2FA sudah aktif")
+	}
+	if user.TOTPSecret == nil {
+		return nil, utils.ErrValidation("Daftarkan authenticator terlebih dahulu")
+	}
+
+	login := &model.LoginUserRequest{Email: user.Email, ClientInfo: request.ClientInfo}
+	if err := c.checkLoginBlocked(ctx, login); err != nil {
+		return nil, err
+	}
+
+	valid, err := c.verifyTOTP(ctx, user, request.Code)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, c.secondFactorFailed(ctx, login)
+	}
+	c.loginSucceeded(ctx, login)
+
+	recoveryCodes, err := c.activateTwoFactor(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	return &model.TwoFactorRecoveryCodesResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+// DisableTwoFactor meminta faktor kedua sekali lagi agar access token yang dicuri saja tidak cukup untuk mematikan 2FA
+func (c *userUseCaseImpl) DisableTwoFactor(ctx context.Context, userID uint, request *model.TwoFactorDisableRequest) error {
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warn().Msgf("Invalid request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	user, err := c.UserRepository.FindByID(userID)
+	if err != nil {
+		return utils.ErrNotFound("Pengguna")
+	}
+	if !user.TwoFactorEnabled() {
+		return utils.ErrValidation("2FA belum aktif")
+	}
+
+	required, err := c.isTwoFactorRequired(user.Role)
+	if err != nil {
+		return err
+	}
+	if required {
+		return utils.ErrForbidden("2FA wajib untuk role " + string(user.Role))
+	}
+
+	login := &model.LoginUserRequest{Email: user.Email, ClientInfo: request.ClientInfo}
+	if err := c.checkLoginBlocked(ctx, login); err != nil {
+		return err
+	}
+
+	valid, err := c.verifySecondFactor(ctx, user, request.Code, request.RecoveryCode)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return c.secondFactorFailed(ctx, login)
+	}
+	c.loginSucceeded(ctx, login)
+
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.UserRepository.SetTOTP(tx, user.ID, nil, nil); err != nil {
+		c.Log.Error().Msgf("Failed to disable 2FA: %v", err)
+		return fiber.ErrInternalServerError
+	}
+	if err := c.RecoveryCodeRepository.DeleteByUserID(tx, user.ID); err != nil {
+		c.Log.Error().Msgf("Failed to delete recovery codes: %v", err)
+		return fiber.ErrInternalServerError
+	}
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Error().Msgf("Failed to commit transaction: %v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	c.Log.Info().Msgf("2FA disabled for user %d", user.ID)
+	return nil
+}
+
+func (c *userUseCaseImpl) GetTwoFactorRequiredRoles() (*model.TwoFactorRequiredRolesResponse, error) {
+	roles, err := c.twoFactorRequiredRoles()
+	if err != nil {
+		return nil, err
+	}
+	return &model.TwoFactorRequiredRolesResponse{Roles: roles}, nil
+}
+
+// SetTwoFactorRequiredRoles hanya berlaku pada login berikutnya; sesi yang sudah berjalan tidak diputus
+func (c *userUseCaseImpl) SetTwoFactorRequiredRoles(request *model.TwoFactorRequiredRolesRequest) (*model.TwoFactorRequiredRolesResponse, error) {
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warn().Msgf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	if err := c.SettingRepository.Set(entity.SettingTwoFactorRequiredRoles, strings.Join(request.Roles, " ")); err != nil {
+		c.Log.Error().Msgf("Failed to save 2FA required roles: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	return c.GetTwoFactorRequiredRoles()
+}
+
+func (c *userUseCaseImpl) twoFactorRequiredRoles() ([]string, error) {
+	value, _, err := c.SettingRepository.Get(entity.SettingTwoFactorRequiredRoles)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to get 2FA required roles: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	return strings.Fields(value), nil
+}
+
+func (c *userUseCaseImpl) isTwoFactorRequired(role entity.UserRole) (bool, error) {
+	roles, err := c.twoFactorRequiredRoles()
+	if err != nil {
+		return false, err
+	}
+	for _, r := range roles {
+		if r == string(role) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (c *userUseCaseImpl) findTwoFactorChallenge(ctx context.Context, token string) (*entity.TwoFactorChallenge, *entity.User, error) {
+	challenge, err := c.TwoFactorChallengeRepository.Find(ctx, token)
+	if err != nil {
+		if errors.Is(err, repository.ErrTwoFactorChallengeNotFound) {
+			return nil, nil, ErrInvalidTwoFactorCode
+		}
+		c.Log.Error().Msgf("Failed to find 2FA challenge: %v", err)
+		return nil, nil, fiber.ErrInternalServerError
+	}
+
+	user, err := c.UserRepository.FindByID(challenge.UserID)
+	if err != nil {
+		c.Log.Warn().Msgf("User of 2FA challenge not found: %v", err)
+		return nil, nil, ErrInvalidTwoFactorCode
+	}
+	return challenge, user, nil
+}
+
+// twoFactorChallengeFailed membatalkan challenge setelah terlalu banyak kode salah agar 10^6 kemungkinan kode tidak dapat ditebak.
+// Kegagalan juga dicatat sebagai kegagalan login akun dan IP, sehingga meminta challenge baru tidak mereset hitungan.
+func (c *userUseCaseImpl) twoFactorChallengeFailed(ctx context.Context, token string, login *model.LoginUserRequest) error {
+	if err := c.secondFactorFailed(ctx, login); !errors.Is(err, ErrInvalidTwoFactorCode) {
+		return err
+	}
+
+	failures, err := c.TwoFactorChallengeRepository.RegisterFailure(ctx, token)
+	if err != nil && !errors.Is(err, repository.ErrTwoFactorChallengeNotFound) {
+		c.Log.Error().Msgf("Failed to register 2FA failure: %v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if failures >= int64(c.configInt("two_factor.max_attempts", 5)) {
+		if _, err := c.TwoFactorChallengeRepository.Delete(ctx, token); err != nil {
+			c.Log.Error().Msgf("Failed to delete 2FA challenge: %v", err)
+			return fiber.ErrInternalServerError
+		}
+		c.Log.Warn().Msgf("2FA challenge revoked after %d failed attempts", failures)
+	}
+	return ErrInvalidTwoFactorCode
+}
+
+// secondFactorFailed mencatat kode 2FA yang salah sebagai kegagalan login akun dan IP, termasuk saat 2FA diaktifkan
+// atau dinonaktifkan dengan access token, sehingga kode tidak dapat ditebak tanpa batas lewat endpoint tersebut
+func (c *userUseCaseImpl) secondFactorFailed(ctx context.Context, login *model.LoginUserRequest) error {
+	if err := c.loginFailed(ctx, login); !errors.Is(err, ErrInvalidCredentials) {
+		return err
+	}
+	return ErrInvalidTwoFactorCode
+}
+
+func (c *userUseCaseImpl) enrollTwoFactor(ctx context.Context, user *entity.User) (*model.TwoFactorEnrollResponse, error) {
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		c.Log.Error().Msgf("Failed to generate TOTP secret: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := c.UserRepository.SetTOTP(c.DB.WithContext(ctx), user.ID, &secret, nil); err != nil {
+		c.Log.Error().Msgf("Failed to save TOTP secret: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	issuer := c.cfg.String("two_factor.issuer")
+	if issuer == "" {
+		issuer = c.cfg.String("app.name")
+	}
+
+	return &model.TwoFactorEnrollResponse{
+		Secret:          secret,
+		ProvisioningURI: utils.TOTPProvisioningURI(issuer, user.Email, secret),
+	}, nil
+}
+
+// activateTwoFactor mengaktifkan 2FA dan mengganti seluruh kode pemulihan; kode asli hanya dikembalikan sekali ini
+func (c *userUseCaseImpl) activateTwoFactor(ctx context.Context, user *entity.User) ([]string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		code, err := utils.GenerateRecoveryCode()
+		if err != nil {
+			c.Log.Error().Msgf("Failed to generate recovery code: %v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		codes[i] = code
+		hashes[i] = utils.HashToken(code)
+	}
+
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	now := time.Now()
+	if err := c.UserRepository.SetTOTP(tx, user.ID, user.TOTPSecret, &now); err != nil {
+		c.Log.Error().Msgf("Failed to enable 2FA: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if err := c.RecoveryCodeRepository.Replace(tx, user.ID, hashes); err != nil {
+		c.Log.Error().Msgf("Failed to save recovery codes: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Error().Msgf("Failed to commit transaction: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	user.TOTPEnabledAt = &now
+	c.Log.Info().Msgf("2FA enabled for user %d", user.ID)
+	return codes, nil
+}
+
+func (c *userUseCaseImpl) verifySecondFactor(ctx context.Context, user *entity.User, code, recoveryCode string) (bool, error) {
+	if code != "" {
+		return c.verifyTOTP(ctx, user, code)
+	}
+
+	consumed, err := c.RecoveryCodeRepository.Consume(c.DB.WithContext(ctx), user.ID, utils.HashToken(utils.NormalizeRecoveryCode(recoveryCode)))
+	if err != nil {
+		c.Log.Error().Msgf("Failed to consume recovery code: %v", err)
+		return false, fiber.ErrInternalServerError
+	}
+	if consumed {
+		c.Log.Info().Msgf("Recovery code used by user %d", user.ID)
+	}
+	return consumed, nil
+}
+
+// verifyTOTP menolak kode dari time step yang sudah pernah dipakai sehingga kode yang disadap tidak dapat diputar ulang
+func (c *userUseCaseImpl) verifyTOTP(ctx context.Context, user *entity.User, code string) (bool, error) {
+	if user.TOTPSecret == nil {
+		return false, nil
+	}
+
+	step, valid := utils.ValidateTOTP(*user.TOTPSecret, code, time.Now())
+	if !valid {
+		return false, nil
+	}
+
+	fresh, err := c.ThrottleRepository.Allow(ctx, fmt.Sprintf("totp:%d:%d", user.ID, step), 3*utils.TOTPPeriod*time.Second)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to check TOTP replay: %v", err)
+		return false, fiber.ErrInternalServerError
+	}
+	return fresh, nil
+}
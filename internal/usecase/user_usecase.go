@@ -3,13 +3,18 @@ package usecase
 import (
 	"context"
 	"errors"
-	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/alexedwards/argon2id"
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/gateway/mail"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/gateway/oidc"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/gateway/pwned"
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model/converter"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/policy"
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/repository"
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
 	"github.com/go-playground/validator/v10"
@@ -21,65 +26,110 @@ import (
 )
 
 type userUseCaseImpl struct {
-	DB             *gorm.DB
-	Log            *zerolog.Logger
-	cfg            *koanf.Koanf
-	Validate       *validator.Validate
-	UserRepository repository.UserRepository
+	DB                           *gorm.DB
+	Log                          *zerolog.Logger
+	cfg                          *koanf.Koanf
+	Validate                     *validator.Validate
+	Policy                       *policy.Engine
+	Audit                        AuditUseCase
+	AccountDeletion              AccountDeletionUseCase
+	Mailer                       mail.Mailer
+	BreachChecker                pwned.Checker
+	PasswordPolicy               utils.PasswordPolicy
+	UserRepository               repository.UserRepository
+	TokenRepository              repository.TokenRepository
+	EmailVerificationRepository  repository.EmailVerificationRepository
+	PasswordResetRepository      repository.PasswordResetRepository
+	ThrottleRepository           repository.ThrottleRepository
+	LoginAttemptRepository       repository.LoginAttemptRepository
+	RecoveryCodeRepository       repository.RecoveryCodeRepository
+	TwoFactorChallengeRepository repository.TwoFactorChallengeRepository
+	SettingRepository            repository.SettingRepository
+	UserIdentityRepository       repository.UserIdentityRepository
+	OIDCStateRepository          repository.OIDCStateRepository
+	SessionRepository            repository.SessionRepository
+	InvitationRepository         repository.InvitationRepository
+	OIDCProviders                map[string]oidc.Provider
+	JWTKeys                      *utils.KeySet
+	HashParams                   *argon2id.Params
+
+	dummyPasswordHash     string
+	dummyPasswordHashOnce sync.Once
 }
 
 type UserUseCase interface {
 	Verify(ctx context.Context, request *model.VerifyUserRequest) (*model.Auth, error)
+	ResendVerification(ctx context.Context, request *model.ResendVerificationRequest) error
+	ForgotPassword(ctx context.Context, request *model.ForgotPasswordRequest) error
+	ResetPassword(ctx context.Context, request *model.ResetPasswordRequest) error
 	Create(ctx context.Context, request *model.RegisterUserRequest) (*model.UserResponse, error)
 	Login(ctx context.Context, request *model.LoginUserRequest) (*model.UserResponse, error)
+	Refresh(ctx context.Context, request *model.RefreshTokenRequest) (*model.UserResponse, error)
+	Logout(ctx context.Context, request *model.LogoutUserRequest) error
+	LogoutAll(ctx context.Context, userID uint) error
 	GetAllUsers() ([]entity.User, error)
 	GetUserByID(id uint) (*entity.User, error)
-	UpdateUser(id uint, username, email, password, role *string) (*entity.User, error)
-	DeleteUser(id uint) error
+	UpdateUser(id uint, username, email, password, role *string, auth *model.Auth) (*entity.User, error)
+	DeleteUser(id uint, auth *model.Auth) error
+	UnlockLogin(ctx context.Context, id uint, auth *model.Auth) error
+	PasswordHashReport() (*model.PasswordHashReportResponse, error)
+	VerifyTwoFactor(ctx context.Context, request *model.TwoFactorVerifyRequest) (*model.UserResponse, error)
+	SetupTwoFactor(ctx context.Context, request *model.TwoFactorSetupRequest) (*model.TwoFactorEnrollResponse, error)
+	EnrollTwoFactor(ctx context.Context, userID uint) (*model.TwoFactorEnrollResponse, error)
+	EnableTwoFactor(ctx context.Context, userID uint, request *model.TwoFactorEnableRequest) (*model.TwoFactorRecoveryCodesResponse, error)
+	DisableTwoFactor(ctx context.Context, userID uint, request *model.TwoFactorDisableRequest) error
+	GetTwoFactorRequiredRoles() (*model.TwoFactorRequiredRolesResponse, error)
+	SetTwoFactorRequiredRoles(request *model.TwoFactorRequiredRolesRequest) (*model.TwoFactorRequiredRolesResponse, error)
+	OIDCAuthorizationURL(ctx context.Context, providerName string) (string, error)
+	OIDCCallback(ctx context.Context, request *model.OIDCCallbackRequest) (*model.UserResponse, error)
+	GetRegistrationMode() (*model.RegistrationModeResponse, error)
+	SetRegistrationMode(auth *model.Auth, request *model.RegistrationModeRequest) (*model.RegistrationModeResponse, error)
+	CreateInvitation(ctx context.Context, auth *model.Auth, request *model.CreateInvitationRequest) (*model.InvitationResponse, error)
+	ListInvitations() ([]model.InvitationResponse, error)
+	RevokeInvitation(auth *model.Auth, id uint) error
+	Impersonate(ctx context.Context, auth *model.Auth, userID uint) (*model.ImpersonationResponse, error)
 }
 
 var (
-	JwtSecret string
-	JwtExpire int
+	JwtExpire        int
+	JwtRefreshExpire int
 )
 
-func NewUserUseCase(db *gorm.DB, log *zerolog.Logger, validate *validator.Validate, UserRepository repository.UserRepository, config *koanf.Koanf) *userUseCaseImpl {
+func NewUserUseCase(db *gorm.DB, log *zerolog.Logger, validate *validator.Validate, policyEngine *policy.Engine, auditUseCase AuditUseCase, accountDeletionUseCase AccountDeletionUseCase, mailer mail.Mailer, breachChecker pwned.Checker, UserRepository repository.UserRepository, TokenRepository repository.TokenRepository, EmailVerificationRepository repository.EmailVerificationRepository, PasswordResetRepository repository.PasswordResetRepository, ThrottleRepository repository.ThrottleRepository, LoginAttemptRepository repository.LoginAttemptRepository, RecoveryCodeRepository repository.RecoveryCodeRepository, TwoFactorChallengeRepository repository.TwoFactorChallengeRepository, SettingRepository repository.SettingRepository, UserIdentityRepository repository.UserIdentityRepository, OIDCStateRepository repository.OIDCStateRepository, SessionRepository repository.SessionRepository, InvitationRepository repository.InvitationRepository, oidcProviders map[string]oidc.Provider, jwtKeys *utils.KeySet, config *koanf.Koanf) *userUseCaseImpl {
 	JwtExpire = config.Int("jwt.expiration")
-	JwtSecret = config.String("jwt.secret")
-
-	return &userUseCaseImpl{
-		DB:             db,
-		Log:            log,
-		cfg:            config,
-		Validate:       validate,
-		UserRepository: UserRepository,
+	JwtRefreshExpire = config.Int("jwt.refresh_expiration")
+	if JwtRefreshExpire == 0 {
+		JwtRefreshExpire = 7 * 24 * 60
 	}
-}
-
-func (c *userUseCaseImpl) Verify(ctx context.Context, request *model.VerifyUserRequest) (*model.Auth, error) {
-	tx := c.DB.WithContext(ctx).Begin()
-	defer tx.Rollback()
 
-	err := c.Validate.Struct(request)
-	if err != nil {
-		c.Log.Warn().Msgf("Invalid reques body : %v", err)
-		return nil, fiber.ErrBadRequest
-	}
-
-	user := new(entity.User)
-	if err := c.UserRepository.FindByToken(user, request.Token); err != nil {
-		c.Log.Warn().Msgf("Failed to find user by token : %v", err)
-		return nil, fiber.ErrNotFound
-	}
-
-	if err := tx.Commit().Error; err != nil {
-		c.Log.Warn().Msgf("Failed to commit transaction : %v", err)
-		return nil, fiber.ErrInternalServerError
+	return &userUseCaseImpl{
+		DB:                           db,
+		Log:                          log,
+		cfg:                          config,
+		Validate:                     validate,
+		Policy:                       policyEngine,
+		Audit:                        auditUseCase,
+		AccountDeletion:              accountDeletionUseCase,
+		Mailer:                       mailer,
+		BreachChecker:                breachChecker,
+		PasswordPolicy:               newPasswordPolicy(config),
+		HashParams:                   newHashParams(config),
+		UserRepository:               UserRepository,
+		TokenRepository:              TokenRepository,
+		EmailVerificationRepository:  EmailVerificationRepository,
+		PasswordResetRepository:      PasswordResetRepository,
+		ThrottleRepository:           ThrottleRepository,
+		LoginAttemptRepository:       LoginAttemptRepository,
+		RecoveryCodeRepository:       RecoveryCodeRepository,
+		TwoFactorChallengeRepository: TwoFactorChallengeRepository,
+		SettingRepository:            SettingRepository,
+		UserIdentityRepository:       UserIdentityRepository,
+		OIDCStateRepository:          OIDCStateRepository,
+		SessionRepository:            SessionRepository,
+		InvitationRepository:         InvitationRepository,
+		OIDCProviders:                oidcProviders,
+		JWTKeys:                      jwtKeys,
 	}
-
-	return &model.Auth{
-		ID: user.ID,
-	}, nil
 }
 
 func (c *userUseCaseImpl) Create(ctx context.Context, request *model.RegisterUserRequest) (*model.UserResponse, error) {
@@ -103,7 +153,11 @@ func (c *userUseCaseImpl) Create(ctx context.Context, request *model.RegisterUse
 		return nil, fiber.ErrConflict
 	}
 
-	password, err := argon2id.CreateHash(request.Password, argon2id.DefaultParams)
+	if err := c.checkPassword(request.Password, request.Username, request.Email); err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	password, err := c.hashPassword(request.Password)
 	if err != nil {
 		c.Log.Warn().Msgf("Failed to hash password : %v", err)
 		return nil, fiber.ErrInternalServerError
@@ -115,16 +169,28 @@ func (c *userUseCaseImpl) Create(ctx context.Context, request *model.RegisterUse
 		Username:     request.Username,
 	}
 
+	if err := c.applyInvitation(tx, request.InvitationCode, user); err != nil {
+		return nil, err
+	}
+
 	if err := c.UserRepository.Create(tx, user); err != nil {
 		c.Log.Warn().Msgf("Failed to create user : %v", err)
 		return nil, fiber.ErrInternalServerError
 	}
 
+	verificationToken, err := c.createVerificationToken(tx, user.ID)
+	if err != nil {
+		c.Log.Warn().Msgf("Failed to create verification token : %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		c.Log.Warn().Msgf("Failed to commit transaction : %v", err)
 		return nil, fiber.ErrInternalServerError
 	}
 
+	c.sendVerificationEmail(user, verificationToken)
+
 	return converter.UserToResponse(user), nil
 }
 
@@ -137,34 +203,232 @@ func (c *userUseCaseImpl) Login(ctx context.Context, request *model.LoginUserReq
 		return nil, fiber.ErrBadRequest
 	}
 
+	if err := c.checkLoginBlocked(ctx, request); err != nil {
+		return nil, err
+	}
+
 	user, err := c.UserRepository.FindByEmail(request.Email)
 	if err != nil {
-		c.Log.Warn().Msgf("User not found: %v", err)
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Log.Error().Msgf("Failed to find user by email: %v", err)
 
-		return nil, fiber.ErrNotFound
+			return nil, fiber.ErrInternalServerError
+		}
+		c.Log.Warn().Msg("Login failed: user not found")
+		c.compareDummyPassword(request.Password)
+
+		return nil, c.loginFailed(ctx, request)
 	}
-	fmt.Println(user)
 
 	match, err := argon2id.ComparePasswordAndHash(request.Password, user.PasswordHash)
 	if err != nil || !match {
-		c.Log.Warn().Msg("Password mismatch")
+		c.Log.Warn().Msg("Login failed: password mismatch")
+
+		return nil, c.loginFailed(ctx, request)
+	}
+
+	c.rehashPasswordIfOutdated(user, request.Password)
+
+	// counter kegagalan baru direset setelah 2FA lolos; jika tidak, password yang bocor cukup untuk
+	// terus meminta challenge baru dan menebak kode TOTP tanpa pernah terkena lockout
+	challenge, err := c.startTwoFactorChallenge(ctx, user)
+	if err != nil || challenge != nil {
+		return challenge, err
+	}
+
+	c.loginSucceeded(ctx, request)
+	return c.startSession(ctx, user, &request.ClientInfo)
+}
+
+func (c *userUseCaseImpl) Refresh(ctx context.Context, request *model.RefreshTokenRequest) (*model.UserResponse, error) {
+	err := c.Validate.Struct(request)
+	if err != nil {
+		c.Log.Warn().Msgf("Invalid request body: %v", err)
+
+		return nil, fiber.ErrBadRequest
+	}
+
+	refreshToken, err := c.TokenRepository.ConsumeRefreshToken(ctx, request.RefreshToken, refreshTokenTTL())
+	if err != nil {
+		if errors.Is(err, repository.ErrRefreshTokenReused) {
+			c.Log.Warn().Msgf("Refresh token reuse detected, revoking family %s", refreshToken.FamilyID)
+			if err := revokeSession(ctx, c.Log, c.SessionRepository, c.TokenRepository, refreshToken.UserID, refreshToken.FamilyID); err != nil {
+				return nil, err
+			}
+			return nil, fiber.ErrUnauthorized
+		}
+		if errors.Is(err, repository.ErrRefreshTokenNotFound) {
+			c.Log.Warn().Msg("Refresh token not found or expired")
+
+			return nil, fiber.ErrUnauthorized
+		}
+		c.Log.Error().Msgf("Failed to consume refresh token: %v", err)
+
+		return nil, fiber.ErrInternalServerError
+	}
+
+	user, err := c.UserRepository.FindByID(refreshToken.UserID)
+	if err != nil {
+		c.Log.Warn().Msgf("User for refresh token not found: %v", err)
+		if err := c.TokenRepository.RevokeRefreshFamily(ctx, refreshToken.FamilyID); err != nil {
+			c.Log.Error().Msgf("Failed to revoke refresh token family: %v", err)
+		}
 
 		return nil, fiber.ErrUnauthorized
 	}
 
-	accessToken, err := utils.GenerateToken(user.ID, user.Role, JwtSecret, JwtExpire)
+	err = c.SessionRepository.Extend(ctx, user.ID, refreshToken.FamilyID, refreshTokenTTL())
+	if errors.Is(err, repository.ErrSessionNotFound) {
+		// family yang dibuat sebelum pelacakan sesi belum memiliki catatan sesi
+		err = c.createSession(ctx, user.ID, refreshToken.FamilyID, &request.ClientInfo)
+	}
+	if err != nil {
+		c.Log.Error().Msgf("Failed to extend session: %v", err)
+
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return c.issueTokens(ctx, user, refreshToken.FamilyID)
+}
+
+func (c *userUseCaseImpl) Logout(ctx context.Context, request *model.LogoutUserRequest) error {
+	err := c.Validate.Struct(request)
+	if err != nil {
+		c.Log.Warn().Msgf("Invalid request body: %v", err)
+
+		return fiber.ErrBadRequest
+	}
+
+	if err := c.TokenRepository.RevokeAccessToken(ctx, request.TokenID, time.Until(request.ExpiresAt)); err != nil {
+		c.Log.Error().Msgf("Failed to revoke access token: %v", err)
+
+		return fiber.ErrInternalServerError
+	}
+
+	if request.SessionID != "" {
+		return revokeSession(ctx, c.Log, c.SessionRepository, c.TokenRepository, request.UserID, request.SessionID)
+	}
+
+	if request.RefreshToken == "" {
+		return nil
+	}
+
+	refreshToken, err := c.TokenRepository.ConsumeRefreshToken(ctx, request.RefreshToken, refreshTokenTTL())
+	if err != nil && !errors.Is(err, repository.ErrRefreshTokenReused) {
+		if errors.Is(err, repository.ErrRefreshTokenNotFound) {
+			return nil
+		}
+		c.Log.Error().Msgf("Failed to consume refresh token: %v", err)
+
+		return fiber.ErrInternalServerError
+	}
+
+	if err := c.TokenRepository.RevokeRefreshFamily(ctx, refreshToken.FamilyID); err != nil {
+		c.Log.Error().Msgf("Failed to revoke refresh token family: %v", err)
+
+		return fiber.ErrInternalServerError
+	}
+	return nil
+}
+
+// LogoutAll menaikkan token version user sehingga seluruh access token lama ditolak oleh JWTMiddleware
+func (c *userUseCaseImpl) LogoutAll(ctx context.Context, userID uint) error {
+	if _, err := c.TokenRepository.IncrementTokenVersion(ctx, userID); err != nil {
+		c.Log.Error().Msgf("Failed to increment token version: %v", err)
+
+		return fiber.ErrInternalServerError
+	}
+
+	if err := c.TokenRepository.RevokeAllRefreshFamilies(ctx, userID); err != nil {
+		c.Log.Error().Msgf("Failed to revoke refresh token families: %v", err)
+
+		return fiber.ErrInternalServerError
+	}
+
+	if _, err := c.SessionRepository.DeleteByUserID(ctx, userID); err != nil {
+		c.Log.Error().Msgf("Failed to delete sessions: %v", err)
+
+		return fiber.ErrInternalServerError
+	}
+	return nil
+}
+
+// startSession membuka sesi baru untuk perangkat yang login. ID sesi sama dengan ID refresh token family
+// dan dibawa sebagai klaim sid pada access token sehingga sesi dapat dicabut satu per satu.
+func (c *userUseCaseImpl) startSession(ctx context.Context, user *entity.User, client *model.ClientInfo) (*model.UserResponse, error) {
+	sessionID, err := utils.GenerateRandomToken(16)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to generate session id: %v", err)
+
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := c.createSession(ctx, user.ID, sessionID, client); err != nil {
+		c.Log.Error().Msgf("Failed to create session: %v", err)
+
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return c.issueTokens(ctx, user, sessionID)
+}
+
+func (c *userUseCaseImpl) createSession(ctx context.Context, userID uint, sessionID string, client *model.ClientInfo) error {
+	now := time.Now()
+	return c.SessionRepository.Create(ctx, &entity.Session{
+		ID:           sessionID,
+		UserID:       userID,
+		UserAgent:    client.UserAgent,
+		IP:           client.IP,
+		CreatedAt:    now,
+		LastActiveAt: now,
+	}, refreshTokenTTL())
+}
+
+// issueTokens membuat access token baru dan refresh token berikutnya dalam family yang sama
+func (c *userUseCaseImpl) issueTokens(ctx context.Context, user *entity.User, familyID string) (*model.UserResponse, error) {
+	version, err := c.TokenRepository.GetTokenVersion(ctx, user.ID)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to get token version: %v", err)
+
+		return nil, fiber.ErrInternalServerError
+	}
+
+	accessToken, err := utils.GenerateToken(user.ID, user.Role, version, familyID, c.JWTKeys, JwtExpire)
 	if err != nil {
 		c.Log.Error().Msgf("Failed to generate access token: %v", err)
 
 		return nil, fiber.ErrInternalServerError
 	}
+
+	refreshToken, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to generate refresh token: %v", err)
+
+		return nil, fiber.ErrInternalServerError
+	}
+
+	err = c.TokenRepository.StoreRefreshToken(ctx, refreshToken, &entity.RefreshToken{
+		UserID:   user.ID,
+		FamilyID: familyID,
+	}, refreshTokenTTL())
+	if err != nil {
+		c.Log.Error().Msgf("Failed to store refresh token: %v", err)
+
+		return nil, fiber.ErrInternalServerError
+	}
+
 	return &model.UserResponse{
-		ID:       user.ID,
-		Username: user.Username,
-		Token:    accessToken,
+		ID:           user.ID,
+		Username:     user.Username,
+		Token:        accessToken,
+		RefreshToken: refreshToken,
 	}, nil
 }
 
+func refreshTokenTTL() time.Duration {
+	return time.Duration(JwtRefreshExpire) * time.Minute
+}
+
 func (s *userUseCaseImpl) GetAllUsers() ([]entity.User, error) {
 	users, err := s.UserRepository.FindAll()
 	if err != nil {
@@ -184,7 +448,7 @@ func (s *userUseCaseImpl) GetUserByID(id uint) (*entity.User, error) {
 	return user, nil
 }
 
-func (s *userUseCaseImpl) UpdateUser(id uint, username, email, password, role *string) (*entity.User, error) {
+func (s *userUseCaseImpl) UpdateUser(id uint, username, email, password, role *string, auth *model.Auth) (*entity.User, error) {
 	user, err := s.UserRepository.FindByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -193,7 +457,22 @@ func (s *userUseCaseImpl) UpdateUser(id uint, username, email, password, role *s
 		return nil, errors.New("Gagal menemukan pengguna: " + err.Error())
 	}
 
-	user.Username = *username
+	resource := policy.Resource{Type: policy.ResourceUser, OwnerID: user.ID}
+	if err := s.Policy.Authorize(auth, policy.ActionUpdate, resource); err != nil {
+		return nil, err
+	}
+	if role != nil && *role != "" {
+		if err := s.Policy.Authorize(auth, policy.ActionChangeRole, resource); err != nil {
+			return nil, err
+		}
+	}
+
+	before := *user
+	changes := model.AuditChanges{}
+
+	if username != nil && *username != "" {
+		user.Username = *username
+	}
 
 	if email != nil && *email != "" {
 		if !strings.EqualFold(user.Email, *email) {
@@ -205,35 +484,61 @@ func (s *userUseCaseImpl) UpdateUser(id uint, username, email, password, role *s
 				return nil, utils.ErrValidation("Email '" + *email + "' sudah digunakan")
 			}
 			user.Email = *email
+			user.EmailVerifiedAt = nil
 		}
 	}
 
 	if password != nil && *password != "" {
-		hashedPassword, err := argon2id.CreateHash(*password, argon2id.DefaultParams)
+		if err := s.checkPassword(*password, user.Username, user.Email); err != nil {
+			return nil, err
+		}
+		hashedPassword, err := s.hashPassword(*password)
 		if err != nil {
 			return nil, errors.New("Gagal mengenkripsi password: " + err.Error())
 		}
 		user.PasswordHash = string(hashedPassword)
+		changes["password"] = model.AuditChange{After: "changed"}
 	}
 
+	roleChanged := false
 	if role != nil && *role != "" {
-		switch entity.UserRole(*role) {
-		case entity.UserRoleReader, entity.UserRoleAuthor, entity.UserRoleAdmin:
-			user.Role = entity.UserRole(*role)
-		default:
+		if !entity.UserRole(*role).IsValid() {
 			return nil, utils.ErrValidation("Role pengguna tidak valid: " + *role)
 		}
+		roleChanged = user.Role != entity.UserRole(*role)
+		user.Role = entity.UserRole(*role)
 	}
 
 	err = s.UserRepository.Update(s.DB, user)
 	if err != nil {
 		return nil, errors.New("Gagal memperbarui pengguna: " + err.Error())
 	}
+
+	// nilai username dan email tidak disimpan: audit_logs append-only sehingga tidak ikut di-scrub saat akun dihapus
+	if before.Username != user.Username {
+		changes["username"] = model.AuditChange{After: "changed"}
+	}
+	if before.Email != user.Email {
+		changes["email"] = model.AuditChange{After: "changed"}
+	}
+	if len(changes) > 0 {
+		s.Audit.Record(auth, entity.AuditActionUserUpdate, entity.AuditTargetUser, user.ID, changes)
+	}
+
+	// Access token lama masih membawa role sebelumnya, jadi paksa klien mengambil token baru lewat refresh
+	if roleChanged {
+		s.Audit.Record(auth, entity.AuditActionUserRoleChange, entity.AuditTargetUser, user.ID, model.AuditChanges{
+			"role": {Before: string(before.Role), After: string(user.Role)},
+		})
+		if _, err := s.TokenRepository.IncrementTokenVersion(context.Background(), user.ID); err != nil {
+			s.Log.Error().Msgf("Failed to increment token version: %v", err)
+		}
+	}
 	return user, nil
 }
 
-func (s *userUseCaseImpl) DeleteUser(id uint) error {
-	_, err := s.UserRepository.FindByID(id)
+func (s *userUseCaseImpl) DeleteUser(id uint, auth *model.Auth) error {
+	user, err := s.UserRepository.FindByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return utils.ErrNotFound("Pengguna")
@@ -241,9 +546,14 @@ func (s *userUseCaseImpl) DeleteUser(id uint) error {
 		return errors.New("Gagal menemukan pengguna: " + err.Error())
 	}
 
-	err = s.UserRepository.Delete(id)
-	if err != nil {
-		return errors.New("Gagal menghapus pengguna: " + err.Error())
+	if err := s.Policy.Authorize(auth, policy.ActionDelete, policy.Resource{Type: policy.ResourceUser, OwnerID: user.ID}); err != nil {
+		return err
 	}
-	return nil
+
+	// akun sendiri dihapus lewat /auth/me/deletion agar melewati konfirmasi password dan masa tenggang
+	if auth.ID == user.ID {
+		return utils.ErrForbidden("Gunakan POST /api/v1/auth/me/deletion untuk menghapus akun sendiri")
+	}
+
+	return s.AccountDeletion.Delete(context.Background(), auth, user)
 }
@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/model"
+	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func (c *userUseCaseImpl) Verify(ctx context.Context, request *model.VerifyUserRequest) (*model.Auth, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	err := c.Validate.Struct(request)
+	if err != nil {
+		c.Log.Warn().Msgf("Invalid reques body : %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	token, err := c.EmailVerificationRepository.FindValidByHash(utils.HashToken(request.Token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Log.Warn().Msg("Verification token not found or expired")
+			return nil, fiber.ErrNotFound
+		}
+		c.Log.Warn().Msgf("Failed to find verification token : %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := c.UserRepository.MarkEmailVerified(tx, token.UserID); err != nil {
+		c.Log.Warn().Msgf("Failed to mark email as verified : %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := c.EmailVerificationRepository.DeleteByUserID(tx, token.UserID); err != nil {
+		c.Log.Warn().Msgf("Failed to delete verification tokens : %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warn().Msgf("Failed to commit transaction : %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return &model.Auth{
+		ID: token.UserID,
+	}, nil
+}
+
+// ResendVerification selalu berhasil untuk email yang tidak terdaftar atau sudah terverifikasi,
+// sehingga endpoint ini tidak bisa dipakai untuk menebak email yang terdaftar.
+func (c *userUseCaseImpl) ResendVerification(ctx context.Context, request *model.ResendVerificationRequest) error {
+	err := c.Validate.Struct(request)
+	if err != nil {
+		c.Log.Warn().Msgf("Invalid request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	interval := time.Duration(c.cfg.Int("verification.resend_interval")) * time.Second
+	if interval == 0 {
+		interval = time.Minute
+	}
+
+	allowed, err := c.ThrottleRepository.Allow(ctx, "verification_resend:"+strings.ToLower(request.Email), interval)
+	if err != nil {
+		c.Log.Error().Msgf("Failed to check resend throttle: %v", err)
+		return fiber.ErrInternalServerError
+	}
+	if !allowed {
+		return fiber.ErrTooManyRequests
+	}
+
+	user, err := c.UserRepository.FindByEmail(request.Email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		c.Log.Error().Msgf("Failed to find user by email: %v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if user.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.EmailVerificationRepository.DeleteByUserID(tx, user.ID); err != nil {
+		c.Log.Warn().Msgf("Failed to delete verification tokens : %v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	verificationToken, err := c.createVerificationToken(tx, user.ID)
+	if err != nil {
+		c.Log.Warn().Msgf("Failed to create verification token : %v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warn().Msgf("Failed to commit transaction : %v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	c.sendVerificationEmail(user, verificationToken)
+	return nil
+}
+
+// createVerificationToken menyimpan hash token dan mengembalikan token asli untuk dikirim lewat email
+func (c *userUseCaseImpl) createVerificationToken(tx *gorm.DB, userID uint) (string, error) {
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return "", err
+	}
+
+	expiration := c.cfg.Int("verification.expiration")
+	if expiration == 0 {
+		expiration = 24 * 60
+	}
+
+	err = c.EmailVerificationRepository.Create(tx, &entity.EmailVerificationToken{
+		UserID:    userID,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().Add(time.Duration(expiration) * time.Minute),
+	})
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func (c *userUseCaseImpl) sendVerificationEmail(user *entity.User, token string) {
+	link := strings.TrimRight(c.cfg.String("app.url"), "/") + "/api/v1/auth/verify?token=" + url.QueryEscape(token)
+	body := "Halo " + user.Username + ",\n\nSilakan verifikasi email Anda melalui tautan berikut:\n" + link + "\n"
+
+	if err := c.Mailer.Send(user.Email, "Verifikasi email Anda", body); err != nil {
+		c.Log.Error().Msgf("Failed to send verification email: %v", err)
+	}
+}
@@ -0,0 +1,149 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// maxDiffCells membatasi ukuran tabel LCS (baris berubah lama × baris berubah baru) agar satu request diff
+// tidak bisa menghabiskan memori server; 1<<20 sel kira-kira 8 MB
+const maxDiffCells = 1 << 20
+
+var ErrDiffTooLarge = errors.New("perbedaan kedua teks terlalu besar untuk ditampilkan")
+
+type diffOp struct {
+	kind byte
+	line string
+	// posisi baris (0-based) di teks lama dan baru sebelum op ini diterapkan
+	from, to int
+}
+
+// UnifiedDiff membandingkan dua teks per baris dan menghasilkan unified diff seperti `diff -u`, dengan
+// context baris tak berubah di sekitar setiap perubahan. Hasilnya string kosong jika kedua teks sama, dan
+// ErrDiffTooLarge jika bagian yang berubah melebihi maxDiffCells.
+func UnifiedDiff(fromName, toName, from, to string, context int) (string, error) {
+	ops, err := diffLines(splitLines(from), splitLines(to))
+	if err != nil {
+		return "", err
+	}
+
+	var out strings.Builder
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			i++
+			continue
+		}
+
+		start := max(i-context, 0)
+		end := i
+		// gabungkan perubahan yang dipisah paling banyak dua kali context baris tak berubah ke hunk yang sama
+		for j := i; j < len(ops) && j <= end+2*context+1; j++ {
+			if ops[j].kind != ' ' {
+				end = j
+			}
+		}
+		end = min(end+context+1, len(ops))
+
+		if out.Len() == 0 {
+			fmt.Fprintf(&out, "--- %s\n+++ %s\n", fromName, toName)
+		}
+		writeHunk(&out, ops[start:end])
+		i = end
+	}
+	return out.String(), nil
+}
+
+func writeHunk(out *strings.Builder, ops []diffOp) {
+	var fromCount, toCount int
+	for _, op := range ops {
+		if op.kind != '+' {
+			fromCount++
+		}
+		if op.kind != '-' {
+			toCount++
+		}
+	}
+
+	fmt.Fprintf(out, "@@ -%s +%s @@\n", hunkRange(ops[0].from, fromCount), hunkRange(ops[0].to, toCount))
+	for _, op := range ops {
+		out.WriteByte(op.kind)
+		out.WriteString(op.line)
+		out.WriteByte('\n')
+	}
+}
+
+// hunkRange mengikuti format diff -u: baris kosong ditulis dengan posisi sebelum hunk dan jumlah 0
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// diffLines mencari longest common subsequence antar baris. Awalan dan akhiran yang sama dipotong lebih dulu
+// agar tabel LCS hanya mencakup bagian yang berubah.
+func diffLines(a, b []string) ([]diffOp, error) {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]diffOp, 0, len(a)+len(b))
+	for i := 0; i < prefix; i++ {
+		ops = append(ops, diffOp{kind: ' ', line: a[i], from: i, to: i})
+	}
+
+	midA, midB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	n, m := len(midA), len(midB)
+	if (n+1)*(m+1) > maxDiffCells {
+		return nil, ErrDiffTooLarge
+	}
+
+	// lcs[i][j] adalah panjang LCS dari midA[i:] dan midB[j:]
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if midA[i] == midB[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && midA[i] == midB[j]:
+			ops = append(ops, diffOp{kind: ' ', line: midA[i], from: prefix + i, to: prefix + j})
+			i++
+			j++
+		case j < m && (i == n || lcs[i][j+1] > lcs[i+1][j]):
+			ops = append(ops, diffOp{kind: '+', line: midB[j], from: prefix + i, to: prefix + j})
+			j++
+		default:
+			ops = append(ops, diffOp{kind: '-', line: midA[i], from: prefix + i, to: prefix + j})
+			i++
+		}
+	}
+
+	for k := 0; k < suffix; k++ {
+		ops = append(ops, diffOp{kind: ' ', line: a[len(a)-suffix+k], from: len(a) - suffix + k, to: len(b) - suffix + k})
+	}
+	return ops, nil
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
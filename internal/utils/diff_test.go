@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		context int
+		want    string
+	}{
+		{
+			name: "teks sama",
+			from: "a\nb\n",
+			to:   "a\nb\n",
+			want: "",
+		},
+		{
+			name: "keduanya kosong",
+			want: "",
+		},
+		{
+			name: "dari teks kosong",
+			to:   "x\n",
+			want: "--- lama\n+++ baru\n@@ -0,0 +1,1 @@\n+x\n",
+		},
+		{
+			name: "menjadi teks kosong",
+			from: "x\ny\n",
+			want: "--- lama\n+++ baru\n@@ -1,2 +0,0 @@\n-x\n-y\n",
+		},
+		{
+			name:    "satu baris diganti",
+			from:    "a\nb\nc\n",
+			to:      "a\nB\nc\n",
+			context: 1,
+			want:    "--- lama\n+++ baru\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+		},
+		{
+			name:    "tanpa newline di akhir tetap dibandingkan per baris",
+			from:    "a\nb",
+			to:      "a\nb\nc",
+			context: 3,
+			want:    "--- lama\n+++ baru\n@@ -1,2 +1,3 @@\n a\n b\n+c\n",
+		},
+		{
+			name:    "perubahan berjauhan menjadi dua hunk",
+			from:    "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			to:      "X\n2\n3\n4\n5\n6\n7\n8\nY\n",
+			context: 1,
+			want:    "--- lama\n+++ baru\n@@ -1,2 +1,2 @@\n-1\n+X\n 2\n@@ -8,2 +8,2 @@\n 8\n-9\n+Y\n",
+		},
+		{
+			name:    "perubahan berdekatan digabung ke satu hunk",
+			from:    "1\n2\n3\n4\n5\n",
+			to:      "X\n2\n3\n4\nY\n",
+			context: 2,
+			want:    "--- lama\n+++ baru\n@@ -1,5 +1,5 @@\n-1\n+X\n 2\n 3\n 4\n-5\n+Y\n",
+		},
+		{
+			name:    "jarak tepat dua kali context tetap satu hunk seperti diff -u",
+			from:    "1\n2\n3\n4\n5\n",
+			to:      "X\n2\n3\nY\n5\n",
+			context: 1,
+			want:    "--- lama\n+++ baru\n@@ -1,5 +1,5 @@\n-1\n+X\n 2\n 3\n-4\n+Y\n 5\n",
+		},
+		{
+			name: "context nol",
+			from: "a\nb\nc\n",
+			to:   "a\nB\nc\n",
+			want: "--- lama\n+++ baru\n@@ -2,1 +2,1 @@\n-b\n+B\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnifiedDiff("lama", "baru", tt.from, tt.to, tt.context)
+			if err != nil {
+				t.Fatalf("UnifiedDiff() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("UnifiedDiff() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnifiedDiffTooLarge(t *testing.T) {
+	from := strings.Repeat("a\n", 2000)
+	to := strings.Repeat("b\n", 2000)
+
+	if _, err := UnifiedDiff("lama", "baru", from, to, 3); !errors.Is(err, ErrDiffTooLarge) {
+		t.Fatalf("UnifiedDiff() error = %v, want ErrDiffTooLarge", err)
+	}
+}
+
+func TestUnifiedDiffLargeUnchangedText(t *testing.T) {
+	// awalan dan akhiran yang sama tidak masuk tabel LCS, sehingga teks panjang dengan sedikit perubahan tetap bisa di-diff
+	common := strings.Repeat("baris\n", 100000)
+	got, err := UnifiedDiff("lama", "baru", common+"a\n"+common, common+"b\n"+common, 0)
+	if err != nil {
+		t.Fatalf("UnifiedDiff() error = %v", err)
+	}
+	if want := "--- lama\n+++ baru\n@@ -100001,1 +100001,1 @@\n-a\n+b\n"; got != want {
+		t.Errorf("UnifiedDiff() =\n%s\nwant\n%s", got, want)
+	}
+}
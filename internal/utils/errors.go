@@ -3,6 +3,7 @@ package utils
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
 type ErrNotFound string
@@ -61,3 +62,17 @@ func IsErrInternal(err error) bool {
 	return errors.As(err, &e)
 }
 
+// ErrTooManyRequests membawa sisa waktu tunggu agar controller dapat mengisi header Retry-After
+type ErrTooManyRequests struct {
+	RetryAfter time.Duration
+}
+
+func (e ErrTooManyRequests) Error() string {
+	return "Terlalu banyak percobaan, silakan coba lagi nanti"
+}
+
+func AsErrTooManyRequests(err error) (ErrTooManyRequests, bool) {
+	var e ErrTooManyRequests
+	ok := errors.As(err, &e)
+	return e, ok
+}
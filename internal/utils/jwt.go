@@ -2,53 +2,224 @@
 package utils
 
 import (
+	"crypto"
+	"crypto/ed25519"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/fernanda-syafalam/backend-monitoring-notification/internal/entity"
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// LegacyKeyID dipakai untuk key HS256 dari jwt.secret dan untuk token lama yang belum membawa header kid
+const LegacyKeyID = "default"
+
 type Claims struct {
-	UserID string       `json:"user_id"`
-	Role   string     `json:"role"`
+	UserID  string `json:"user_id"`
+	Role    string `json:"role"`
+	Version int64  `json:"ver"`
+	// SessionID sama dengan ID refresh token family; kosong untuk token yang terbit sebelum sesi dicatat
+	SessionID string `json:"sid,omitempty"`
+	// Actor hanya ada pada token impersonation (RFC 8693): sub adalah user yang ditiru, act.sub adalah admin yang meniru
+	Actor *ActorClaim `json:"act,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func GenerateToken(userID uint, role entity.UserRole, secret string, expirationMinutes int) (string, error) {
-	fmt.Println(expirationMinutes)
+type ActorClaim struct {
+	Subject string `json:"sub"`
+}
+
+// ImpersonatorID mengembalikan ID admin yang menerbitkan token impersonation, atau 0 untuk token biasa
+func (c *Claims) ImpersonatorID() uint {
+	if c.Actor == nil {
+		return 0
+	}
+	id, err := strconv.ParseUint(c.Actor.Subject, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return uint(id)
+}
+
+// SigningKey adalah satu key JWT yang dikenali lewat kid. PrivateKey boleh kosong untuk key lama
+// yang hanya dipakai memverifikasi token selama masa rotasi.
+type SigningKey struct {
+	ID         string
+	Method     jwt.SigningMethod
+	PrivateKey crypto.PrivateKey
+	PublicKey  crypto.PublicKey
+}
+
+// KeySet menyimpan key aktif untuk menandatangani token dan semua key yang masih diterima saat verifikasi
+type KeySet struct {
+	active *SigningKey
+	keys   map[string]*SigningKey
+}
+
+func NewKeySet(activeKID string, keys ...*SigningKey) (*KeySet, error) {
+	set := &KeySet{keys: make(map[string]*SigningKey, len(keys))}
+	for _, key := range keys {
+		if _, exists := set.keys[key.ID]; exists {
+			return nil, fmt.Errorf("kid %q terdaftar lebih dari sekali", key.ID)
+		}
+		set.keys[key.ID] = key
+	}
+
+	active, ok := set.keys[activeKID]
+	if !ok {
+		return nil, fmt.Errorf("key aktif %q tidak ditemukan", activeKID)
+	}
+	if active.PrivateKey == nil {
+		return nil, fmt.Errorf("key aktif %q tidak memiliki private key", activeKID)
+	}
+	set.active = active
+
+	return set, nil
+}
+
+// NewHMACKey membuat key HS256 dari shared secret. Key ini tidak pernah dipublikasikan di JWKS.
+func NewHMACKey(kid string, secret []byte) *SigningKey {
+	return &SigningKey{
+		ID:         kid,
+		Method:     jwt.SigningMethodHS256,
+		PrivateKey: secret,
+		PublicKey:  secret,
+	}
+}
+
+// NewSigningKeyFromPEM memuat key RS256/RS384/RS512 atau EdDSA dari PEM. Salah satu dari privatePEM
+// atau publicPEM boleh kosong; public key diturunkan dari private key jika tidak diberikan.
+func NewSigningKeyFromPEM(kid, algorithm string, privatePEM, publicPEM []byte) (*SigningKey, error) {
+	if len(privatePEM) == 0 && len(publicPEM) == 0 {
+		return nil, fmt.Errorf("key %q tidak memiliki private maupun public key", kid)
+	}
+
+	key := &SigningKey{ID: kid, Method: jwt.GetSigningMethod(algorithm)}
+
+	switch key.Method.(type) {
+	case *jwt.SigningMethodRSA:
+		if len(privatePEM) > 0 {
+			privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(privatePEM)
+			if err != nil {
+				return nil, fmt.Errorf("key %q: %w", kid, err)
+			}
+			key.PrivateKey = privateKey
+			key.PublicKey = &privateKey.PublicKey
+		}
+		if len(publicPEM) > 0 {
+			publicKey, err := jwt.ParseRSAPublicKeyFromPEM(publicPEM)
+			if err != nil {
+				return nil, fmt.Errorf("key %q: %w", kid, err)
+			}
+			key.PublicKey = publicKey
+		}
+	case *jwt.SigningMethodEd25519:
+		if len(privatePEM) > 0 {
+			privateKey, err := jwt.ParseEdPrivateKeyFromPEM(privatePEM)
+			if err != nil {
+				return nil, fmt.Errorf("key %q: %w", kid, err)
+			}
+			key.PrivateKey = privateKey
+			key.PublicKey = privateKey.(ed25519.PrivateKey).Public()
+		}
+		if len(publicPEM) > 0 {
+			publicKey, err := jwt.ParseEdPublicKeyFromPEM(publicPEM)
+			if err != nil {
+				return nil, fmt.Errorf("key %q: %w", kid, err)
+			}
+			key.PublicKey = publicKey
+		}
+	default:
+		return nil, fmt.Errorf("key %q: algoritma %q tidak didukung", kid, algorithm)
+	}
+
+	return key, nil
+}
+
+// Active mengembalikan key yang dipakai untuk menandatangani token baru
+func (s *KeySet) Active() *SigningKey {
+	return s.active
+}
+
+func (s *KeySet) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		kid = LegacyKeyID
+	}
+
+	key, ok := s.keys[kid]
+	if !ok {
+		return nil, errors.New("kid tidak dikenal")
+	}
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, errors.New("metode penandatanganan tidak valid")
+	}
+	return key.PublicKey, nil
+}
+
+// GenerateToken menandatangani access token. version harus sama dengan token version user di Redis
+// agar token tidak ditolak setelah "logout everywhere".
+func GenerateToken(userID uint, role entity.UserRole, version int64, sessionID string, keys *KeySet, expirationMinutes int) (string, error) {
+	claims, err := newClaims(userID, role, version, expirationMinutes)
+	if err != nil {
+		return "", err
+	}
+	claims.SessionID = sessionID
+	return signClaims(claims, keys)
+}
+
+// GenerateImpersonationToken menandatangani access token atas nama userID untuk admin actorID.
+// Token ini tidak terikat sesi dan tidak memiliki refresh token, sehingga berakhir saat kedaluwarsa.
+func GenerateImpersonationToken(userID uint, role entity.UserRole, version int64, actorID uint, keys *KeySet, expirationMinutes int) (string, error) {
+	claims, err := newClaims(userID, role, version, expirationMinutes)
+	if err != nil {
+		return "", err
+	}
+	claims.Actor = &ActorClaim{Subject: fmt.Sprintf("%d", actorID)}
+	return signClaims(claims, keys)
+}
+
+func newClaims(userID uint, role entity.UserRole, version int64, expirationMinutes int) (*Claims, error) {
 	expirationTime := time.Now().Add(time.Duration(expirationMinutes) * time.Minute)
 	uidStr := fmt.Sprintf("%d", userID)
 
-	claims := &Claims{
-		UserID: uidStr,
-		Role:   string(role),
+	jti, err := GenerateRandomToken(16)
+	if err != nil {
+		return nil, errors.New("gagal membuat ID token")
+	}
+
+	return &Claims{
+		UserID:  uidStr,
+		Role:    string(role),
+		Version: version,
 		RegisteredClaims: jwt.RegisteredClaims{
-			Subject:   uidStr, 
+			ID:        jti,
+			Subject:   uidStr,
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 		},
-	}
+	}, nil
+}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(secret))
+func signClaims(claims *Claims, keys *KeySet) (string, error) {
+	signingKey := keys.Active()
+	token := jwt.NewWithClaims(signingKey.Method, claims)
+	token.Header["kid"] = signingKey.ID
+	tokenString, err := token.SignedString(signingKey.PrivateKey)
 	if err != nil {
 		return "", errors.New("gagal menandatangani token")
 	}
 	return tokenString, nil
 }
 
-
-func ParseToken(tokenString, secret string) (*Claims, error) {
+// ParseToken memverifikasi token dengan key sesuai header kid, sehingga token yang ditandatangani
+// key lama tetap valid selama key tersebut masih terdaftar.
+func ParseToken(tokenString string, keys *KeySet) (*Claims, error) {
 	claims := &Claims{}
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("metode penandatanganan tidak valid")
-		}
-		return []byte(secret), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, claims, keys.keyFunc)
 
 	if err != nil {
 		return nil, err
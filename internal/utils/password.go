@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// PasswordPolicy adalah aturan password baru yang berlaku untuk registrasi, perubahan profil, dan reset password
+type PasswordPolicy struct {
+	MinLength      int
+	RequireUpper   bool
+	RequireLower   bool
+	RequireDigit   bool
+	RequireSymbol  bool
+	RejectIdentity bool
+}
+
+// Check mengembalikan ErrValidation pertama yang dilanggar. identities berisi username dan email pemilik
+// password; bagian lokal email juga diperiksa agar "budi@example.com" tidak dapat memakai "budi2024!".
+func (p PasswordPolicy) Check(password string, identities ...string) error {
+	if len([]rune(password)) < p.MinLength {
+		return ErrValidation(fmt.Sprintf("Password minimal %d karakter", p.MinLength))
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+
+	var missing []string
+	if p.RequireUpper && !upper {
+		missing = append(missing, "huruf besar")
+	}
+	if p.RequireLower && !lower {
+		missing = append(missing, "huruf kecil")
+	}
+	if p.RequireDigit && !digit {
+		missing = append(missing, "angka")
+	}
+	if p.RequireSymbol && !symbol {
+		missing = append(missing, "simbol")
+	}
+	if len(missing) > 0 {
+		return ErrValidation("Password harus mengandung " + strings.Join(missing, ", "))
+	}
+
+	if p.RejectIdentity {
+		lowered := strings.ToLower(password)
+		for _, identity := range passwordIdentities(identities) {
+			if strings.Contains(lowered, identity) {
+				return ErrValidation("Password tidak boleh mengandung username atau email")
+			}
+		}
+	}
+	return nil
+}
+
+// passwordIdentities mengabaikan potongan di bawah tiga karakter supaya username pendek tidak menolak terlalu banyak password
+func passwordIdentities(identities []string) []string {
+	var result []string
+	for _, identity := range identities {
+		identity = strings.ToLower(strings.TrimSpace(identity))
+		candidates := []string{identity}
+		if local, _, found := strings.Cut(identity, "@"); found {
+			candidates = append(candidates, local)
+		}
+		for _, candidate := range candidates {
+			if len(candidate) >= 3 {
+				result = append(result, candidate)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+// rfc6238Secret adalah kunci SHA1 "12345678901234567890" dari lampiran B RFC 6238 dalam base32
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCodeRFC6238(t *testing.T) {
+	key, err := totpEncoding.DecodeString(rfc6238Secret)
+	if err != nil {
+		t.Fatalf("DecodeString() error = %v", err)
+	}
+
+	// RFC 6238 memakai 8 digit; kode 6 digit adalah 6 digit terakhirnya
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		if got := totpCode(key, tt.unix/TOTPPeriod); got != tt.want {
+			t.Errorf("totpCode(%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	current := now.Unix() / TOTPPeriod
+	key, err := totpEncoding.DecodeString(rfc6238Secret)
+	if err != nil {
+		t.Fatalf("DecodeString() error = %v", err)
+	}
+	codeAt := func(step int64) string { return totpCode(key, step) }
+
+	tests := []struct {
+		name      string
+		secret    string
+		code      string
+		wantStep  int64
+		wantValid bool
+	}{
+		{"step saat ini", rfc6238Secret, codeAt(current), current, true},
+		{"satu step sebelumnya", rfc6238Secret, codeAt(current - 1), current - 1, true},
+		{"satu step sesudahnya", rfc6238Secret, codeAt(current + 1), current + 1, true},
+		{"dua step sebelumnya di luar window", rfc6238Secret, codeAt(current - 2), 0, false},
+		{"dua step sesudahnya di luar window", rfc6238Secret, codeAt(current + 2), 0, false},
+		{"secret huruf kecil", "gezdgnbvgy3tqojqgezdgnbvgy3tqojq", codeAt(current), current, true},
+		{"kode salah", rfc6238Secret, "000000", 0, false},
+		{"kode terlalu pendek", rfc6238Secret, codeAt(current)[:5], 0, false},
+		{"kode 8 digit", rfc6238Secret, "14050471", 0, false},
+		{"secret bukan base32", "bukan-base32!", codeAt(current), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, valid := ValidateTOTP(tt.secret, tt.code, now)
+			if valid != tt.wantValid || step != tt.wantStep {
+				t.Errorf("ValidateTOTP() = (%d, %v), want (%d, %v)", step, valid, tt.wantStep, tt.wantValid)
+			}
+		})
+	}
+}
+
+func TestValidateTOTPGeneratedSecret(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatalf("GenerateTOTPSecret() error = %v", err)
+	}
+	key, err := totpEncoding.DecodeString(secret)
+	if err != nil {
+		t.Fatalf("DecodeString() error = %v", err)
+	}
+	if len(key) != 20 {
+		t.Fatalf("len(key) = %d, want 20", len(key))
+	}
+
+	now := time.Now()
+	if _, valid := ValidateTOTP(secret, totpCode(key, now.Unix()/TOTPPeriod), now); !valid {
+		t.Error("ValidateTOTP() menolak kode dari secret yang baru dibuat")
+	}
+}
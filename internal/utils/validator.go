@@ -46,6 +46,14 @@ func GetValidationMessage(fe validator.FieldError) string {
 		return fe.Field() + " harus berupa alamat email yang valid"
 	case "unique": // Contoh custom tag
 		return fe.Field() + " sudah digunakan"
+	case "http_url":
+		return fe.Field() + " harus berupa URL http atau https yang valid"
+	case "optional_http_url":
+		return fe.Field() + " harus kosong atau berupa URL http atau https yang valid"
+	case "user_role":
+		return fe.Field() + " harus salah satu dari: admin, author, reader"
+	case "api_key_scope":
+		return fe.Field() + " bukan scope API key yang dikenal"
 	default:
 		return fe.Field() + " tidak valid"
 	}